@@ -0,0 +1,89 @@
+package go_json_errors_parser
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"testing"
+)
+
+func BenchmarkParseFixtures(b *testing.B) {
+	files, err := filepath.Glob("tests/example*.json")
+	if err != nil {
+		b.Fatal(err)
+	}
+	sort.Strings(files)
+
+	for _, file := range files {
+		data, err := ioutil.ReadFile(file)
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		b.Run(filepath.Base(file), func(b *testing.B) {
+			b.ReportAllocs()
+			b.SetBytes(int64(len(data)))
+			for i := 0; i < b.N; i++ {
+				Parse(data)
+			}
+		})
+	}
+}
+
+// Returns bulk API response with n items, every tenth item having validation errors
+func bulkResponse(n int) []byte {
+	var buf bytes.Buffer
+
+	buf.WriteString(`{"took": 30, "items": [`)
+	for i := 0; i < n; i++ {
+		if i > 0 {
+			buf.WriteString(",")
+		}
+		fmt.Fprintf(&buf, `{"id": %d, "status": 201, "result": {"name": "item %d", "tags": ["a", "b"], "enabled": true, "ratio": 0.5}`, i, i)
+		if i%10 == 0 {
+			fmt.Fprintf(&buf, `, "errors": {"name": ["is too long", "has invalid characters"], "price": ["must be positive"]}, "error_message": "Validation failed for item %d"`, i)
+		}
+		buf.WriteString("}")
+	}
+	buf.WriteString(`]}`)
+
+	return buf.Bytes()
+}
+
+// Returns document nested depth times with errors at the bottom
+func nestedDocument(depth int) []byte {
+	var buf bytes.Buffer
+	for i := 0; i < depth; i++ {
+		fmt.Fprintf(&buf, `{"level%d": `, i)
+	}
+	buf.WriteString(`{"errors": {"name": ["required"]}}`)
+	for i := 0; i < depth; i++ {
+		buf.WriteString("}")
+	}
+	return buf.Bytes()
+}
+
+func BenchmarkParseLarge(b *testing.B) {
+	for _, n := range []int{100, 10000} {
+		data := bulkResponse(n)
+
+		b.Run(fmt.Sprintf("bulk-%d", n), func(b *testing.B) {
+			b.ReportAllocs()
+			b.SetBytes(int64(len(data)))
+			for i := 0; i < b.N; i++ {
+				Parse(data)
+			}
+		})
+	}
+
+	data := nestedDocument(100)
+	b.Run("nested-100", func(b *testing.B) {
+		b.ReportAllocs()
+		b.SetBytes(int64(len(data)))
+		for i := 0; i < b.N; i++ {
+			Parse(data)
+		}
+	})
+}
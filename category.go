@@ -0,0 +1,312 @@
+package go_json_errors_parser
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Category of error, used to decide on retries and alerting
+type Category int
+
+const (
+	CategoryUnknown Category = iota
+	CategoryAuth
+	CategoryValidation
+	CategoryNotFound
+	CategoryConflict
+	CategoryRateLimit
+	CategoryServer
+)
+
+var categoryNames = []string{"unknown", "auth", "validation", "not_found", "conflict", "rate_limit", "server"}
+
+func (c Category) String() string {
+	if c < 0 || int(c) >= len(categoryNames) {
+		return "unknown"
+	}
+	return categoryNames[c]
+}
+
+// Marshals category by name
+func (c Category) MarshalText() ([]byte, error) {
+	return []byte(c.String()), nil
+}
+
+func (c *Category) UnmarshalText(text []byte) error {
+	for i, name := range categoryNames {
+		if name == string(text) {
+			*c = Category(i)
+			return nil
+		}
+	}
+	return fmt.Errorf("jerrparser: unknown category %q", text)
+}
+
+// Reports whether request failed with error of this category may succeed if repeated
+func (c Category) IsRetryable() bool {
+	return c == CategoryRateLimit || c == CategoryServer
+}
+
+// Reports whether error of this category is caused by request, like 4xx HTTP statuses
+func (c Category) IsClientError() bool {
+	switch c {
+	case CategoryAuth, CategoryValidation, CategoryNotFound, CategoryConflict, CategoryRateLimit:
+		return true
+	}
+	return false
+}
+
+// Returns category of HTTP status
+func categoryOfStatus(status int) Category {
+	switch status {
+	case 400, 422:
+		return CategoryValidation
+	case 401, 403:
+		return CategoryAuth
+	case 404, 410:
+		return CategoryNotFound
+	case 409:
+		return CategoryConflict
+	case 429:
+		return CategoryRateLimit
+	}
+	if status >= 500 && status <= 599 {
+		return CategoryServer
+	}
+	return CategoryUnknown
+}
+
+// Returns category of error code: HTTP status, google.rpc.Code name or well-known GraphQL code
+func categoryOfCode(code string) Category {
+	if status, err := strconv.Atoi(code); err == nil {
+		return categoryOfStatus(status)
+	}
+
+	switch strings.ToUpper(code) {
+	case "INVALID_ARGUMENT", "FAILED_PRECONDITION", "OUT_OF_RANGE", "BAD_USER_INPUT", "GRAPHQL_VALIDATION_FAILED", "GRAPHQL_PARSE_FAILED":
+		return CategoryValidation
+	case "UNAUTHENTICATED", "PERMISSION_DENIED", "FORBIDDEN":
+		return CategoryAuth
+	case "NOT_FOUND":
+		return CategoryNotFound
+	case "ALREADY_EXISTS", "ABORTED":
+		return CategoryConflict
+	case "RESOURCE_EXHAUSTED":
+		return CategoryRateLimit
+	case "INTERNAL", "UNAVAILABLE", "DATA_LOSS", "UNKNOWN", "DEADLINE_EXCEEDED", "INTERNAL_SERVER_ERROR":
+		return CategoryServer
+	}
+	return CategoryUnknown
+}
+
+// Returns category of JSON-RPC error
+func categoryOfRPC(kind JSONRPCErrorKind) Category {
+	switch kind {
+	case RPCParseError, RPCInvalidRequest, RPCInvalidParams:
+		return CategoryValidation
+	case RPCMethodNotFound:
+		return CategoryNotFound
+	case RPCInternalError, RPCServerError:
+		return CategoryServer
+	}
+	return CategoryUnknown
+}
+
+// CategoryRule returns category of error, CategoryUnknown if rule doesn't apply
+type CategoryRule func(e ParsedError) Category
+
+// Categorizes by HTTP status
+func StatusRule(e ParsedError) Category {
+	return categoryOfStatus(e.Status)
+}
+
+// Categorizes by numeric, google.rpc.Code or GraphQL error code, and JSON-RPC errors
+// by kind of their code only, as application JSON-RPC codes are not HTTP statuses
+func CodeRule(e ParsedError) Category {
+	if e.RPC != nil {
+		return categoryOfRPC(e.RPC.Kind)
+	}
+	return categoryOfCode(e.Code)
+}
+
+// Categorizes errors with children as field validation errors
+func FieldsRule(e ParsedError) Category {
+	if len(e.Children) > 0 {
+		return CategoryValidation
+	}
+	return CategoryUnknown
+}
+
+// Keyword found in error messages
+type Keyword struct {
+	Text     string
+	Category Category
+}
+
+// Keywords used by default keyword rule
+func DefaultKeywords() []Keyword {
+	return []Keyword{
+		{"unauthorized", CategoryAuth},
+		{"unauthenticated", CategoryAuth},
+		{"forbidden", CategoryAuth},
+		{"permission denied", CategoryAuth},
+		{"access denied", CategoryAuth},
+		{"auth required", CategoryAuth},
+		{"too many requests", CategoryRateLimit},
+		{"rate limit", CategoryRateLimit},
+		{"quota exceeded", CategoryRateLimit},
+		{"not found", CategoryNotFound},
+		{"already exists", CategoryConflict},
+		{"conflict", CategoryConflict},
+		{"internal server error", CategoryServer},
+		{"service unavailable", CategoryServer},
+		{"bad gateway", CategoryServer},
+		{"timed out", CategoryServer},
+		{"validation", CategoryValidation},
+		{"invalid", CategoryValidation},
+		{"required", CategoryValidation},
+	}
+}
+
+// Returns rule categorizing by the first keyword found in messages, case-insensitively
+func KeywordRule(keywords []Keyword) CategoryRule {
+	return func(e ParsedError) Category {
+		for _, keyword := range keywords {
+			text := strings.ToLower(keyword.Text)
+			for _, msg := range e.Messages {
+				if strings.Contains(strings.ToLower(msg), text) {
+					return keyword.Category
+				}
+			}
+		}
+		return CategoryUnknown
+	}
+}
+
+// Rules used by parser by default, the first rule giving known category wins
+func DefaultCategoryRules() []CategoryRule {
+	return []CategoryRule{StatusRule, CodeRule, FieldsRule, KeywordRule(DefaultKeywords())}
+}
+
+// Sets rules categorizing parsed errors, for example
+// WithCategoryRules(append([]CategoryRule{myRule}, DefaultCategoryRules()...)...)
+func WithCategoryRules(rules ...CategoryRule) Option {
+	return func(p *Parser) {
+		p.categoryRules = rules
+	}
+}
+
+// Sets category of errors not categorized yet
+func (p *Parser) categorize(errs []ParsedError) {
+	for i := range errs {
+		if errs[i].Category != CategoryUnknown {
+			continue
+		}
+
+		for _, rule := range p.categoryRules {
+			if c := rule(errs[i]); c != CategoryUnknown {
+				errs[i].Category = c
+				break
+			}
+		}
+	}
+}
+
+// Reports whether request may succeed if repeated
+func (e ParsedError) IsRetryable() bool {
+	return e.Category.IsRetryable()
+}
+
+// Reports whether error is caused by request
+func (e ParsedError) IsClientError() bool {
+	return e.Category.IsClientError()
+}
+
+// Returns category of response status if errors were parsed from response,
+// otherwise the category of the most severe error
+func (pe *ParsedErrors) Category() Category {
+	if pe.Response != nil {
+		if c := categoryOfStatus(pe.Response.StatusCode); c != CategoryUnknown {
+			return c
+		}
+	}
+
+	category := CategoryUnknown
+	for _, e := range pe.ParsedErrors {
+		if categorySeverity(e.Category) > categorySeverity(category) {
+			category = e.Category
+		}
+	}
+	return category
+}
+
+// Reports whether request may succeed if repeated
+func (pe *ParsedErrors) IsRetryable() bool {
+	return pe.Category().IsRetryable()
+}
+
+// Reports whether errors are caused by request
+func (pe *ParsedErrors) IsClientError() bool {
+	return pe.Category().IsClientError()
+}
+
+// Severity ranks of categories, higher is more severe
+func categorySeverity(c Category) int {
+	switch c {
+	case CategoryServer:
+		return 6
+	case CategoryAuth:
+		return 5
+	case CategoryRateLimit:
+		return 4
+	case CategoryNotFound, CategoryConflict:
+		return 3
+	case CategoryValidation:
+		return 2
+	}
+	return 0
+}
+
+// Fills empty code and status of errors found under keys of object
+// from its "code" and "status" members, like {"error": "Unauthorized", "code": 401}
+func stampSiblingCodes(item *node, errs []ParsedError) {
+	code := scalarMember(item, "code")
+	status := scalarMember(item, "status")
+	if code == "" && status == "" {
+		return
+	}
+
+	statusCode, statusErr := strconv.Atoi(status)
+
+	for i := range errs {
+		if errs[i].Code == "" {
+			if code != "" {
+				errs[i].Code = code
+			} else if statusErr != nil && isStatusName(status) {
+				// Status given by name, like "INVALID_ARGUMENT"
+				errs[i].Code = status
+			}
+		}
+		if errs[i].Status == 0 && statusErr == nil {
+			errs[i].Status = statusCode
+		}
+	}
+}
+
+// Reports whether status is google.rpc.Code or other known error code name, so statuses
+// like JSend "error" or "fail" are not taken for codes
+func isStatusName(status string) bool {
+	if code, ok := ParseCanonicalCode(status); ok && code != CodeOK {
+		return true
+	}
+	return categoryOfCode(status) != CategoryUnknown
+}
+
+// Returns string or number member of object, empty string if member has other type
+func scalarMember(item *node, key string) string {
+	if m := item.member(key); m != nil && (m.kind == StringValue || m.kind == NumberValue) {
+		return m.text
+	}
+	return ""
+}
@@ -0,0 +1,99 @@
+package go_json_errors_parser
+
+import (
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestCategoryExample2(t *testing.T) {
+	file, e := ioutil.ReadFile("tests/example2.json")
+	assert.NoError(t, e)
+
+	errs, err := Parse(file)
+	assert.NoError(t, err)
+
+	assert.Equal(t, "401", errs.ParsedErrors[0].Code)
+	assert.Equal(t, CategoryAuth, errs.ParsedErrors[0].Category)
+	assert.Equal(t, CategoryAuth, errs.Category())
+	assert.True(t, errs.IsClientError())
+	assert.False(t, errs.IsRetryable())
+}
+
+func TestCategorySiblingStatusName(t *testing.T) {
+	errs, err := Parse([]byte(`{"error": "Quota exhausted", "status": "RESOURCE_EXHAUSTED"}`))
+	assert.NoError(t, err)
+	assert.Equal(t, "RESOURCE_EXHAUSTED", errs.ParsedErrors[0].Code)
+
+	// JSend status is not a code
+	errs, err = Parse([]byte(`{"status": "error", "message": "Something failed"}`))
+	assert.NoError(t, err)
+	assert.Equal(t, 1, errs.GetCount())
+	assert.Equal(t, "", errs.ParsedErrors[0].Code)
+}
+
+func TestCategoryRules(t *testing.T) {
+	cases := []struct {
+		doc      string
+		category Category
+	}{
+		{`{"Errors": ["Unauthorized", "Auth required"]}`, CategoryAuth},
+		{`{"error": {"code": 400, "message": "bad", "status": "INVALID_ARGUMENT"}}`, CategoryValidation},
+		{`{"error": "Quota exhausted", "status": "RESOURCE_EXHAUSTED"}`, CategoryRateLimit},
+		{`{"error": "Something happened", "status": 503}`, CategoryServer},
+		{`{"errors": {"name": ["is required"]}}`, CategoryValidation},
+		{`{"error": "User not found"}`, CategoryNotFound},
+		{`{"error": "Something happened"}`, CategoryUnknown},
+	}
+
+	for _, c := range cases {
+		errs, err := Parse([]byte(c.doc))
+		assert.NoError(t, err)
+		assert.Equal(t, c.category, errs.Category(), c.doc)
+	}
+}
+
+func TestCategoryRetryable(t *testing.T) {
+	assert.True(t, CategoryServer.IsRetryable())
+	assert.True(t, CategoryRateLimit.IsRetryable())
+	assert.True(t, CategoryRateLimit.IsClientError())
+	assert.False(t, CategoryValidation.IsRetryable())
+	assert.False(t, CategoryServer.IsClientError())
+	assert.False(t, CategoryUnknown.IsClientError())
+	assert.Equal(t, "rate_limit", CategoryRateLimit.String())
+}
+
+func TestCategoryResponse(t *testing.T) {
+	recorder := httptest.NewRecorder()
+	recorder.Header().Set("Content-Type", "text/html")
+	recorder.WriteHeader(http.StatusServiceUnavailable)
+	recorder.WriteString("<html><title>Maintenance</title></html>")
+
+	errs, err := ParseResponse(recorder.Result())
+	assert.NoError(t, err)
+	assert.Equal(t, CategoryServer, errs.ParsedErrors[0].Category)
+	assert.True(t, errs.IsRetryable())
+}
+
+func TestWithCategoryRules(t *testing.T) {
+	deprecated := func(e ParsedError) Category {
+		for _, msg := range e.Messages {
+			if strings.Contains(msg, "deprecated") {
+				return CategoryValidation
+			}
+		}
+		return CategoryUnknown
+	}
+
+	p := NewParser(WithCategoryRules(append([]CategoryRule{deprecated}, DefaultCategoryRules()...)...))
+	errs, err := p.Parse([]byte(`{"errors": ["API version is deprecated", "Unauthorized"]}`))
+	assert.NoError(t, err)
+	assert.Equal(t, CategoryValidation, errs.ParsedErrors[0].Category)
+
+	errs, err = NewParser(WithCategoryRules()).Parse([]byte(`{"error": "Unauthorized"}`))
+	assert.NoError(t, err)
+	assert.Equal(t, CategoryUnknown, errs.ParsedErrors[0].Category)
+}
@@ -0,0 +1,383 @@
+// Command jerrparse prints errors found in json documents.
+//
+// Usage:
+//
+//	jerrparse [flags] [file ...]
+//
+// Documents are read from files, or from stdin if no file is given.
+// With -ndjson every line of input is parsed as separate document.
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	jerrparser "github.com/inhuman/go-json-errors-parser"
+)
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+// Document read from input, named by file and line for messages
+type document struct {
+	name string
+	data []byte
+}
+
+// Single message or child of parsed error
+type entry struct {
+	pointer   string
+	parent    string
+	child     string
+	message   string
+	extractor string
+	category  jerrparser.Category
+}
+
+type config struct {
+	format  string
+	ndjson  bool
+	paths   bool
+	explain bool
+}
+
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("jerrparse", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.Usage = func() {
+		fmt.Fprintln(stderr, "Usage: jerrparse [flags] [file ...]")
+		fmt.Fprintln(stderr, "Prints errors found in json documents read from files or stdin.")
+		flags.PrintDefaults()
+	}
+
+	var cfg config
+	flags.StringVar(&cfg.format, "format", "lines", "output format: lines, json, table or tree")
+	flags.BoolVar(&cfg.ndjson, "ndjson", false, "parse every input line as separate document")
+	flags.BoolVar(&cfg.paths, "paths", false, "print JSON Pointers of errors instead of parent and child names")
+	flags.BoolVar(&cfg.explain, "explain", false, "print extractor or format every error was found by")
+
+	keys := flags.String("keys", "", "comma-separated glob patterns of extra error keys")
+	excludeKeys := flags.String("exclude-keys", "", "comma-separated glob patterns of keys never holding errors")
+	sortBy := flags.String("sort", "", "comma-separated sort keys: document, path, message, severity")
+	rpc := flags.Bool("jsonrpc", false, "recognize JSON-RPC 2.0 responses")
+	nulls := flags.Bool("nulls", false, "keep null children")
+	legacy := flags.Bool("legacy-slice-maps", false, "extract arrays of objects as single error")
+
+	if err := flags.Parse(args); err != nil {
+		if err == flag.ErrHelp {
+			return 0
+		}
+		return 2
+	}
+
+	switch cfg.format {
+	case "lines", "json", "table", "tree":
+	default:
+		fmt.Fprintf(stderr, "jerrparse: unknown format %q\n", cfg.format)
+		return 2
+	}
+
+	opts, err := parserOptions(*keys, *excludeKeys, *sortBy)
+	if err != nil {
+		fmt.Fprintf(stderr, "jerrparse: %v\n", err)
+		return 2
+	}
+	if *rpc {
+		opts = append(opts, jerrparser.WithJSONRPC())
+	}
+	if *nulls {
+		opts = append(opts, jerrparser.WithNulls())
+	}
+	if *legacy {
+		opts = append(opts, jerrparser.WithLegacySliceMaps())
+	}
+	parser := jerrparser.NewParser(opts...)
+
+	docs, err := readDocuments(flags.Args(), stdin, cfg.ndjson)
+	if err != nil {
+		fmt.Fprintf(stderr, "jerrparse: %v\n", err)
+		return 1
+	}
+
+	status := 0
+	for _, doc := range docs {
+		errs, err := parser.Parse(doc.data)
+		if err != nil {
+			fmt.Fprintf(stderr, "jerrparse: %s: %v\n", doc.name, err)
+			status = 1
+		}
+
+		if len(docs) > 1 && cfg.format != "json" {
+			fmt.Fprintf(stdout, "==> %s <==\n", doc.name)
+		}
+
+		if err := printDocument(stdout, cfg, doc, errs); err != nil {
+			fmt.Fprintf(stderr, "jerrparse: %v\n", err)
+			return 1
+		}
+	}
+
+	return status
+}
+
+func parserOptions(keys, excludeKeys, sortBy string) ([]jerrparser.Option, error) {
+	var opts []jerrparser.Option
+
+	if keys != "" || excludeKeys != "" {
+		include := jerrparser.DefaultKeyPatterns()
+		for _, key := range splitList(keys) {
+			include = append(include, jerrparser.Glob(key))
+		}
+
+		var exclude []jerrparser.KeyPattern
+		for _, key := range splitList(excludeKeys) {
+			exclude = append(exclude, jerrparser.Glob(key))
+		}
+
+		matcher, err := jerrparser.NewKeyMatcher(include, exclude)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, jerrparser.WithKeyMatcher(matcher))
+	}
+
+	if sortBy != "" {
+		sortKeys := map[string]jerrparser.SortKey{
+			"document": jerrparser.SortDocument,
+			"path":     jerrparser.SortPath,
+			"message":  jerrparser.SortMessage,
+			"severity": jerrparser.SortSeverity,
+		}
+
+		var by []jerrparser.SortKey
+		for _, name := range splitList(sortBy) {
+			key, ok := sortKeys[name]
+			if !ok {
+				return nil, fmt.Errorf("unknown sort key %q", name)
+			}
+			by = append(by, key)
+		}
+		opts = append(opts, jerrparser.SortBy(by...))
+	}
+
+	return opts, nil
+}
+
+func splitList(s string) []string {
+	var list []string
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+	return list
+}
+
+// Reads documents from files, stdin if no file given
+func readDocuments(files []string, stdin io.Reader, ndjson bool) ([]document, error) {
+	if len(files) == 0 {
+		files = []string{"-"}
+	}
+
+	var docs []document
+	for _, file := range files {
+		name := file
+		var data []byte
+		var err error
+
+		if file == "-" {
+			name = "stdin"
+			data, err = ioutil.ReadAll(stdin)
+		} else {
+			data, err = ioutil.ReadFile(file)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if !ndjson {
+			docs = append(docs, document{name: name, data: data})
+			continue
+		}
+
+		scanner := bufio.NewScanner(bytes.NewReader(data))
+		scanner.Buffer(nil, len(data)+1)
+		for line := 1; scanner.Scan(); line++ {
+			if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+				continue
+			}
+			docs = append(docs, document{
+				name: fmt.Sprintf("%s:%d", name, line),
+				data: append([]byte(nil), scanner.Bytes()...),
+			})
+		}
+		if err := scanner.Err(); err != nil {
+			return nil, err
+		}
+	}
+
+	return docs, nil
+}
+
+func printDocument(w io.Writer, cfg config, doc document, errs *jerrparser.ParsedErrors) error {
+	switch cfg.format {
+	case "json":
+		return printJSON(w, doc, errs)
+	case "table":
+		return printTable(w, cfg, entries(errs))
+	case "tree":
+		printTree(w, cfg, entries(errs))
+		return nil
+	}
+
+	printLines(w, cfg, errs)
+	return nil
+}
+
+// Prints errors the way GetErrors renders them
+func printLines(w io.Writer, cfg config, errs *jerrparser.ParsedErrors) {
+	var opts []jerrparser.RenderOption
+	if cfg.paths {
+		opts = append(opts, jerrparser.WithPointers())
+	}
+
+	for _, err := range errs.GetErrors(opts...) {
+		line := err.Error()
+
+		var parsed jerrparser.ParsedError
+		if cfg.explain && errors.As(err, &parsed) {
+			line += "\t<- " + explanation(parsed.Extractor)
+		}
+
+		fmt.Fprintln(w, line)
+	}
+}
+
+// Prints one json object per document
+func printJSON(w io.Writer, doc document, errs *jerrparser.ParsedErrors) error {
+	out := struct {
+		Document string
+		*jerrparser.ParsedErrors
+		Category jerrparser.Category
+	}{doc.name, errs, errs.Category()}
+
+	encoder := json.NewEncoder(w)
+	return encoder.Encode(out)
+}
+
+func printTable(w io.Writer, cfg config, entries []entry) error {
+	table := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+
+	header := "PARENT\tCHILD\tCATEGORY\tMESSAGE"
+	if cfg.paths {
+		header = "POINTER\tCATEGORY\tMESSAGE"
+	}
+	if cfg.explain {
+		header += "\tEXTRACTOR"
+	}
+	fmt.Fprintln(table, header)
+
+	for _, e := range entries {
+		row := e.parent + "\t" + e.child + "\t" + e.category.String() + "\t" + e.message
+		if cfg.paths {
+			row = e.pointer + "\t" + e.category.String() + "\t" + e.message
+		}
+		if cfg.explain {
+			row += "\t" + explanation(e.extractor)
+		}
+		fmt.Fprintln(table, row)
+	}
+
+	return table.Flush()
+}
+
+// Prints messages under the JSON Pointer segments leading to them
+func printTree(w io.Writer, cfg config, entries []entry) {
+	var printed []string
+
+	for _, e := range entries {
+		segments := strings.Split(e.pointer, "/")[1:]
+
+		// Segments shared with previous entry are printed already
+		common := 0
+		for common < len(segments) && common < len(printed) && segments[common] == printed[common] {
+			common++
+		}
+
+		for i := common; i < len(segments); i++ {
+			fmt.Fprintf(w, "%s%s\n", strings.Repeat("  ", i), segments[i])
+		}
+		printed = segments
+
+		line := strings.Repeat("  ", len(segments)) + "- " + e.message
+		if cfg.explain {
+			line += "  <- " + explanation(e.extractor)
+		}
+		fmt.Fprintln(w, line)
+	}
+}
+
+func explanation(extractor string) string {
+	if extractor == "" {
+		return "unknown"
+	}
+	return extractor
+}
+
+// Flattens parsed errors to messages and children in document order,
+// children of every error sorted by name
+func entries(errs *jerrparser.ParsedErrors) []entry {
+	var list []entry
+
+	for _, e := range errs.ParsedErrors {
+		for i, msg := range e.Messages {
+			pointer := e.Pointer
+			if i < len(e.MessagePointers) {
+				pointer = e.MessagePointers[i]
+			}
+			list = append(list, entry{
+				pointer:   pointer,
+				parent:    e.Parent,
+				message:   msg,
+				extractor: e.Extractor,
+				category:  e.Category,
+			})
+		}
+
+		var names []string
+		for name := range e.Children {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			for i, child := range e.Children[name] {
+				pointer := e.Pointer + "/" + name
+				if pointers := e.ChildPointers[name]; i < len(pointers) {
+					pointer = pointers[i]
+				}
+				list = append(list, entry{
+					pointer:   pointer,
+					parent:    e.Parent,
+					child:     name,
+					message:   child,
+					extractor: e.Extractor,
+					category:  e.Category,
+				})
+			}
+		}
+	}
+
+	return list
+}
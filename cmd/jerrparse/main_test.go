@@ -0,0 +1,72 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func runWith(t *testing.T, stdin string, args ...string) (string, string, int) {
+	var stdout, stderr bytes.Buffer
+	status := run(args, strings.NewReader(stdin), &stdout, &stderr)
+	return stdout.String(), stderr.String(), status
+}
+
+func TestRunLines(t *testing.T) {
+	out, _, status := runWith(t, "", "../../tests/example4.json")
+	assert.Equal(t, 0, status)
+	assert.Contains(t, out, "[data][materials] A pipeline must have at least one material\n")
+
+	out, _, status = runWith(t, `{"errors": {"name": ["required"]}}`, "-paths", "-explain")
+	assert.Equal(t, 0, status)
+	assert.Equal(t, "[/errors/name/0] required\t<- mapStringSliceInterfaceError\n", out)
+}
+
+func TestRunNDJSON(t *testing.T) {
+	in := `{"error": "Unauthorized"}` + "\n\n" + `{"error": "Not found",}` + "\n"
+
+	out, errOut, status := runWith(t, in, "-ndjson", "-format", "json")
+	assert.Equal(t, 1, status)
+	assert.Contains(t, errOut, "stdin:3")
+
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	assert.Equal(t, 2, len(lines))
+
+	var doc struct {
+		Document     string
+		ParsedErrors []struct{ Messages []string }
+		Category     string
+	}
+	assert.NoError(t, json.Unmarshal([]byte(lines[0]), &doc))
+	assert.Equal(t, "stdin:1", doc.Document)
+	assert.Equal(t, "Unauthorized", doc.ParsedErrors[0].Messages[0])
+	assert.Equal(t, "auth", doc.Category)
+}
+
+func TestRunTableAndTree(t *testing.T) {
+	in := `{"data": {"errors": {"name": ["required"], "age": ["too young"]}}}`
+
+	out, _, status := runWith(t, in, "-format", "table")
+	assert.Equal(t, 0, status)
+	assert.Contains(t, out, "PARENT  CHILD  CATEGORY    MESSAGE\n")
+	assert.Contains(t, out, "data    age    validation  too young\n")
+
+	out, _, status = runWith(t, in, "-format", "tree")
+	assert.Equal(t, 0, status)
+	assert.Equal(t, "data\n  errors\n    age\n      0\n        - too young\n    name\n      0\n        - required\n", out)
+}
+
+func TestRunOptions(t *testing.T) {
+	out, _, status := runWith(t, `{"issues": ["Name is required"]}`, "-keys", "issues")
+	assert.Equal(t, 0, status)
+	assert.Equal(t, "[] Name is required\n", out)
+
+	_, errOut, status := runWith(t, "{}", "-sort", "size")
+	assert.Equal(t, 2, status)
+	assert.Contains(t, errOut, `unknown sort key "size"`)
+
+	_, _, status = runWith(t, "{}", "-format", "xml")
+	assert.Equal(t, 2, status)
+}
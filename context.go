@@ -0,0 +1,64 @@
+package go_json_errors_parser
+
+import (
+	"bytes"
+	"context"
+)
+
+// Number of values read or objects walked between checks of context
+const contextCheckInterval = 256
+
+// Parses given json with default parser, stops when ctx is done
+func ParseContext(ctx context.Context, data []byte) (*ParsedErrors, error) {
+	return defaultParser.ParseContext(ctx, data)
+}
+
+// Parses given json like Parse, checking ctx periodically while decoding and walking.
+// When ctx is done, returns its error wrapped along with errors found so far
+func (p *Parser) ParseContext(ctx context.Context, data []byte) (*ParsedErrors, error) {
+	r := newNodeReader(bytes.NewReader(data), data, p.limits)
+	r.ctx = ctx
+
+	// Parser is shared, context and walk counter are set on its copy
+	parser := *p
+	parser.ctx = ctx
+	parser.walked = 0
+
+	return parser.parse(r)
+}
+
+// Checks context every contextCheckInterval walked objects
+func (p *Parser) checkContext() error {
+	if p.ctx == nil {
+		return nil
+	}
+
+	err := checkContext(p.ctx, p.walked)
+	p.walked++
+	return err
+}
+
+// Returns wrapped context error if ctx is done, checking it only every contextCheckInterval calls
+func checkContext(ctx context.Context, count int) error {
+	if count%contextCheckInterval != 0 {
+		return nil
+	}
+
+	if err := ctx.Err(); err != nil {
+		return &contextError{err: err}
+	}
+	return nil
+}
+
+// Error of done context, errors.Is reports context.Canceled or context.DeadlineExceeded for it
+type contextError struct {
+	err error
+}
+
+func (e *contextError) Error() string {
+	return "jerrparser: parsing stopped: " + e.err.Error()
+}
+
+func (e *contextError) Unwrap() error {
+	return e.err
+}
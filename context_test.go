@@ -0,0 +1,91 @@
+package go_json_errors_parser
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+// Context done after given number of Err calls, counting calls if limit is negative
+type countingContext struct {
+	context.Context
+	calls int
+	limit int
+}
+
+func (c *countingContext) Err() error {
+	c.calls++
+	if c.limit >= 0 && c.calls > c.limit {
+		return context.Canceled
+	}
+	return nil
+}
+
+func TestParseContext(t *testing.T) {
+	data := bulkResponse(100)
+
+	expected, err := Parse(data)
+	assert.NoError(t, err)
+
+	errs, err := ParseContext(context.Background(), data)
+	assert.NoError(t, err)
+	assert.Equal(t, expected.ParsedErrors, errs.ParsedErrors)
+}
+
+func TestParseContextCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	errs, err := ParseContext(ctx, bulkResponse(100))
+
+	assert.True(t, errors.Is(err, context.Canceled))
+	assert.False(t, errs.IsErrors())
+}
+
+func TestParseContextDeadline(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Nanosecond)
+	defer cancel()
+	<-ctx.Done()
+
+	_, err := ParseContext(ctx, bulkResponse(100))
+
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+}
+
+func TestParseContextPartial(t *testing.T) {
+	data := bulkResponse(1000)
+
+	expected, err := Parse(data)
+	assert.NoError(t, err)
+
+	counting := &countingContext{Context: context.Background(), limit: -1}
+	_, err = ParseContext(counting, data)
+	assert.NoError(t, err)
+
+	// Done at the last check, made while walking
+	ctx := &countingContext{Context: context.Background(), limit: counting.calls - 1}
+	errs, err := ParseContext(ctx, data)
+
+	assert.True(t, errors.Is(err, context.Canceled))
+	assert.True(t, errs.IsErrors())
+	assert.Less(t, errs.GetCount(), expected.GetCount())
+	assert.Equal(t, expected.ParsedErrors[0], errs.ParsedErrors[0])
+}
+
+func TestParseContextPartialDecode(t *testing.T) {
+	data := bulkResponse(1000)
+
+	expected, err := Parse(data)
+	assert.NoError(t, err)
+
+	// Done at the third check, made while decoding
+	ctx := &countingContext{Context: context.Background(), limit: 2}
+	errs, err := ParseContext(ctx, data)
+
+	assert.True(t, errors.Is(err, context.Canceled))
+	assert.True(t, errs.IsErrors())
+	assert.Less(t, errs.GetCount(), expected.GetCount())
+	assert.Equal(t, expected.ParsedErrors[0], errs.ParsedErrors[0])
+}
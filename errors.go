@@ -0,0 +1,68 @@
+package go_json_errors_parser
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// SyntaxError is returned when the given document is not valid json
+type SyntaxError struct {
+	// Byte offset of the failure, relative to the value at Path
+	Offset int64
+	// JSON Pointer of the value that failed, empty for the whole document
+	Path string
+	Err  error
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("jerrparser: syntax error at offset %d of %q: %v", e.Offset, e.Path, e.Err)
+}
+
+func (e *SyntaxError) Unwrap() error {
+	return e.Err
+}
+
+// StructureError is returned when a valid json value has a shape
+// the parser can't walk through
+type StructureError struct {
+	// Byte offset of the failure, relative to the value at Path
+	Offset int64
+	// JSON Pointer of the value that failed, empty for the whole document
+	Path string
+	Err  error
+}
+
+func (e *StructureError) Error() string {
+	return fmt.Sprintf("jerrparser: unexpected structure at offset %d of %q: %v", e.Offset, e.Path, e.Err)
+}
+
+func (e *StructureError) Unwrap() error {
+	return e.Err
+}
+
+// Converts json.Unmarshal error to SyntaxError or StructureError
+func wrapUnmarshalError(err error, path []string) error {
+	if err == nil {
+		return nil
+	}
+
+	switch e := err.(type) {
+	case *json.SyntaxError:
+		return &SyntaxError{Offset: e.Offset, Path: jsonPointer(path), Err: err}
+	case *json.UnmarshalTypeError:
+		return &StructureError{Offset: e.Offset, Path: jsonPointer(path), Err: err}
+	default:
+		return &StructureError{Path: jsonPointer(path), Err: err}
+	}
+}
+
+// LimitExceededError is returned when input exceeds one of parser limits
+type LimitExceededError struct {
+	// Name of the exceeded limit
+	Limit string
+	Max   int64
+}
+
+func (e *LimitExceededError) Error() string {
+	return fmt.Sprintf("jerrparser: %s limit of %d exceeded", e.Limit, e.Max)
+}
@@ -0,0 +1,261 @@
+package go_json_errors_parser
+
+import (
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// FieldError is error message found for a field of request body
+type FieldError struct {
+	// Go path of the field, like Pipeline.Materials[1].Destination, empty if key is not resolved
+	Field string
+	// Child key or JSON Pointer field of parsed error the message was found under
+	Key     string
+	Message string
+	// JSON Pointer of the message in parsed document
+	Pointer string
+	// Path of the field in request body, like ["materials", "1", "destination"]
+	Path []string
+}
+
+// FieldErrors holds messages resolved to fields of Go struct,
+// and messages whose keys don't match any field
+type FieldErrors struct {
+	Errors     []FieldError
+	Unresolved []FieldError
+}
+
+// Returns messages of field with given Go path
+func (fe FieldErrors) Messages(field string) []string {
+	var msgs []string
+	for _, e := range fe.Errors {
+		if e.Field == field {
+			msgs = append(msgs, e.Message)
+		}
+	}
+	return msgs
+}
+
+// Maps children of parsed errors, and messages of errors having Field, to fields of the struct v
+// (or pointer to it) by json tags. Keys may be plain names, paths like "materials.1.destination"
+// or "materials[1].destination", or JSON Pointers. Keys are resolved along with the location of
+// the error in document, so errors of array elements find their fields. Leading wrappers,
+// like "data" or "attributes", are dropped until path matches; keys under other objects,
+// like "owner" missing in the struct, are unresolved rather than taken for top-level fields
+func MapToStruct(parsed *ParsedErrors, v interface{}) FieldErrors {
+	var fe FieldErrors
+
+	t := reflect.TypeOf(v)
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	for _, e := range parsed.ParsedErrors {
+		for _, fieldError := range e.FieldMessages() {
+			fe.add(t, fieldError)
+		}
+	}
+
+	return fe
+}
+
+// Returns messages of error having Field, and children of error sorted by name, as field errors
+// with Path set and Field left empty. Field of error, like JSON:API source pointer, is path
+// from document root, while child keys are located in the object the error was found in
+func (e ParsedError) FieldMessages() []FieldError {
+	var fieldErrors []FieldError
+
+	if e.Field != "" {
+		path := keySegments(e.Field)
+		for i, msg := range e.Messages {
+			fieldErrors = append(fieldErrors, FieldError{Key: e.Field, Message: msg, Pointer: e.messagePointer(i), Path: path})
+		}
+	}
+
+	location := errorLocation(e.Pointer)
+
+	var names []string
+	for name := range e.Children {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		path := append(append([]string{}, location...), keySegments(name)...)
+		for i, child := range e.Children[name] {
+			fieldErrors = append(fieldErrors, FieldError{Key: name, Message: child, Pointer: e.childPointer(name, i), Path: path})
+		}
+	}
+
+	return fieldErrors
+}
+
+func (fe *FieldErrors) add(t reflect.Type, e FieldError) {
+	if t != nil && t.Kind() == reflect.Struct {
+		segments := e.Path
+
+		for i := 0; i < len(segments); i++ {
+			if path, ok := resolveField(t, segments[i:]); ok {
+				e.Field = t.Name() + path
+				fe.Errors = append(fe.Errors, e)
+				return
+			}
+			if !isWrapper(t, segments, i) {
+				break
+			}
+		}
+	}
+
+	fe.Unresolved = append(fe.Unresolved, e)
+}
+
+// Returns segments of object the error was found in, without the error key
+// and index of array element holding the error
+func errorLocation(pointer string) []string {
+	segments := pointerSegments(pointer)
+	if len(segments) == 0 {
+		return nil
+	}
+
+	if _, err := strconv.Atoi(segments[len(segments)-1]); err == nil && len(segments) > 1 {
+		segments = segments[:len(segments)-1]
+	}
+	return segments[:len(segments)-1]
+}
+
+// Splits JSON Pointer, or path with dots and brackets, to segments
+func keySegments(key string) []string {
+	if strings.HasPrefix(key, "/") {
+		return pointerSegments(key)
+	}
+
+	key = strings.NewReplacer("[", ".", "]", "").Replace(key)
+
+	var segments []string
+	for _, segment := range strings.Split(key, ".") {
+		if segment != "" {
+			segments = append(segments, segment)
+		}
+	}
+	return segments
+}
+
+var pointerUnescaper = strings.NewReplacer("~1", "/", "~0", "~")
+
+func pointerSegments(pointer string) []string {
+	if pointer == "" {
+		return nil
+	}
+
+	segments := strings.Split(strings.TrimPrefix(pointer, "/"), "/")
+	for i, segment := range segments {
+		segments[i] = pointerUnescaper.Replace(segment)
+	}
+	return segments
+}
+
+// Names of objects request bodies are commonly wrapped in
+var fieldWrappers = []string{"data", "attributes", "params", "body", "payload", "request"}
+
+// Reports whether segment i is a wrapper of struct t: known wrapper name, or index of
+// array of wrappers, which is not a field of the struct itself
+func isWrapper(t reflect.Type, segments []string, i int) bool {
+	if _, ok := fieldByJSONName(t, segments[i]); ok {
+		return false
+	}
+	if _, err := strconv.Atoi(segments[i]); err == nil {
+		return i > 0 && stringInSlice(strings.ToLower(segments[i-1]), fieldWrappers)
+	}
+	return stringInSlice(strings.ToLower(segments[i]), fieldWrappers)
+}
+
+// Returns Go path of field at given json segments, like ".Materials[1].Destination"
+func resolveField(t reflect.Type, segments []string) (string, bool) {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	if len(segments) == 0 {
+		return "", true
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		field, ok := fieldByJSONName(t, segments[0])
+		if !ok {
+			return "", false
+		}
+
+		path, ok := resolveField(field.Type, segments[1:])
+		return "." + field.Name + path, ok
+
+	case reflect.Slice, reflect.Array:
+		i, err := strconv.Atoi(segments[0])
+		if err != nil || i < 0 || t.Kind() == reflect.Array && i >= t.Len() {
+			return "", false
+		}
+
+		path, ok := resolveField(t.Elem(), segments[1:])
+		return "[" + segments[0] + "]" + path, ok
+
+	case reflect.Map:
+		if t.Key().Kind() != reflect.String {
+			return "", false
+		}
+
+		path, ok := resolveField(t.Elem(), segments[1:])
+		return "[" + segments[0] + "]" + path, ok
+	}
+
+	return "", false
+}
+
+// Finds field by json name the way encoding/json does: exact name first, then case-insensitively.
+// Fields of embedded structs are promoted
+func fieldByJSONName(t reflect.Type, name string) (reflect.StructField, bool) {
+	var folded *reflect.StructField
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		tagName := strings.Split(tag, ",")[0]
+
+		if field.Anonymous && tagName == "" {
+			embedded := field.Type
+			if embedded.Kind() == reflect.Ptr {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				if f, ok := fieldByJSONName(embedded, name); ok {
+					return f, true
+				}
+				continue
+			}
+		}
+
+		if field.PkgPath != "" {
+			continue
+		}
+
+		if tagName == "" {
+			tagName = field.Name
+		}
+		if tagName == name {
+			return field, true
+		}
+		if folded == nil && strings.EqualFold(tagName, name) {
+			folded = &field
+		}
+	}
+
+	if folded != nil {
+		return *folded, true
+	}
+	return reflect.StructField{}, false
+}
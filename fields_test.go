@@ -0,0 +1,110 @@
+package go_json_errors_parser
+
+import (
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"testing"
+)
+
+type testMaterial struct {
+	Type        string `json:"type"`
+	Destination string `json:"destination"`
+}
+
+type testBase struct {
+	Name string `json:"name"`
+}
+
+type Pipeline struct {
+	testBase
+	LabelTemplate string            `json:"label_template"`
+	Materials     []testMaterial    `json:"materials"`
+	Labels        map[string]string `json:"labels"`
+	Internal      string            `json:"-"`
+}
+
+func TestMapToStructExample5(t *testing.T) {
+	file, e := ioutil.ReadFile("tests/example5.json")
+	assert.NoError(t, e)
+
+	errs, err := Parse(file)
+	assert.NoError(t, err)
+
+	fields := MapToStruct(errs, &Pipeline{})
+
+	assert.Empty(t, fields.Unresolved)
+	assert.Equal(t, []string{
+		"Invalid Destination Directory. Every material needs a different destination directory and the directories should not be nested.",
+		"The destination directory must be unique across materials.",
+	}, fields.Messages("Pipeline.Materials[1].Destination"))
+	assert.Equal(t, "/data/materials/1/errors/destination/1", fields.Errors[3].Pointer)
+}
+
+func TestMapToStructKeys(t *testing.T) {
+	errs, err := Parse([]byte(`{"errors": {
+		"label_template": ["is invalid"],
+		"materials.0.type": ["is unknown"],
+		"materials[1].destination": ["is nested"],
+		"labels.env": ["is reserved"],
+		"Name": ["is taken"],
+		"internal": ["is hidden"],
+		"stages": ["are empty"]
+	}}`))
+	assert.NoError(t, err)
+
+	fields := MapToStruct(errs, Pipeline{})
+
+	assert.Equal(t, []string{"is invalid"}, fields.Messages("Pipeline.LabelTemplate"))
+	assert.Equal(t, []string{"is unknown"}, fields.Messages("Pipeline.Materials[0].Type"))
+	assert.Equal(t, []string{"is nested"}, fields.Messages("Pipeline.Materials[1].Destination"))
+	assert.Equal(t, []string{"is reserved"}, fields.Messages("Pipeline.Labels[env]"))
+	assert.Equal(t, []string{"is taken"}, fields.Messages("Pipeline.Name"))
+
+	assert.Equal(t, 2, len(fields.Unresolved))
+	assert.Equal(t, "internal", fields.Unresolved[0].Key)
+	assert.Equal(t, "stages", fields.Unresolved[1].Key)
+	assert.Equal(t, "", fields.Unresolved[1].Field)
+}
+
+func TestMapToStructJSONAPI(t *testing.T) {
+	errs, err := Parse([]byte(`{"errors": [
+		{"status": "422", "source": {"pointer": "/data/attributes/label_template"}, "detail": "is too long"},
+		{"status": "422", "source": {"pointer": "/data/attributes/owner"}, "detail": "is unknown"}
+	]}`))
+	assert.NoError(t, err)
+
+	fields := MapToStruct(errs, &Pipeline{})
+
+	assert.Equal(t, []string{"is too long"}, fields.Messages("Pipeline.LabelTemplate"))
+	assert.Equal(t, 1, len(fields.Unresolved))
+	assert.Equal(t, "/data/attributes/owner", fields.Unresolved[0].Key)
+}
+
+func TestMapToStructNested(t *testing.T) {
+	errs, err := Parse([]byte(`{"data": {"owner": {"errors": {"name": ["is taken"]}}},
+		"errors": [{"status": "422", "source": {"pointer": "/data/attributes/owner/name"}, "detail": "is blank"}]}`))
+	assert.NoError(t, err)
+
+	fields := MapToStruct(errs, &Pipeline{})
+
+	// name of owner is not name of pipeline
+	assert.Empty(t, fields.Errors)
+	assert.Equal(t, 2, len(fields.Unresolved))
+}
+
+func TestFieldMessages(t *testing.T) {
+	errs, err := Parse([]byte(`{"data": {"materials": [{"errors": {"destination": ["Invalid"]}}]},
+		"errors": [{"status": "422", "source": {"pointer": "/data/attributes/name"}, "detail": "is blank"}]}`))
+	assert.NoError(t, err)
+
+	var paths [][]string
+	for _, e := range errs.ParsedErrors {
+		for _, fieldError := range e.FieldMessages() {
+			paths = append(paths, fieldError.Path)
+		}
+	}
+
+	// child keys are located in the object of the error, source pointer is taken from root
+	assert.Contains(t, paths, []string{"data", "materials", "0", "destination"})
+	assert.Contains(t, paths, []string{"data", "attributes", "name"})
+}
@@ -0,0 +1,326 @@
+package go_json_errors_parser
+
+import (
+	"encoding/json"
+	"github.com/pkg/errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CanonicalCode is google.rpc.Code, the canonical error code of Google APIs and gRPC
+type CanonicalCode int
+
+const (
+	CodeOK CanonicalCode = iota
+	CodeCanceled
+	CodeUnknown
+	CodeInvalidArgument
+	CodeDeadlineExceeded
+	CodeNotFound
+	CodeAlreadyExists
+	CodePermissionDenied
+	CodeResourceExhausted
+	CodeFailedPrecondition
+	CodeAborted
+	CodeOutOfRange
+	CodeUnimplemented
+	CodeInternal
+	CodeUnavailable
+	CodeDataLoss
+	CodeUnauthenticated
+)
+
+var canonicalCodeNames = []string{
+	"OK",
+	"CANCELLED",
+	"UNKNOWN",
+	"INVALID_ARGUMENT",
+	"DEADLINE_EXCEEDED",
+	"NOT_FOUND",
+	"ALREADY_EXISTS",
+	"PERMISSION_DENIED",
+	"RESOURCE_EXHAUSTED",
+	"FAILED_PRECONDITION",
+	"ABORTED",
+	"OUT_OF_RANGE",
+	"UNIMPLEMENTED",
+	"INTERNAL",
+	"UNAVAILABLE",
+	"DATA_LOSS",
+	"UNAUTHENTICATED",
+}
+
+var canonicalCodeHTTPStatuses = []int{200, 499, 500, 400, 504, 404, 409, 403, 429, 400, 409, 400, 501, 500, 503, 500, 401}
+
+// Returns status name, e.g. "INVALID_ARGUMENT"
+func (c CanonicalCode) String() string {
+	if c < 0 || int(c) >= len(canonicalCodeNames) {
+		return "CODE(" + strconv.Itoa(int(c)) + ")"
+	}
+	return canonicalCodeNames[c]
+}
+
+// Returns HTTP status the code is mapped to by Google APIs
+func (c CanonicalCode) HTTPStatus() int {
+	if c < 0 || int(c) >= len(canonicalCodeHTTPStatuses) {
+		return 500
+	}
+	return canonicalCodeHTTPStatuses[c]
+}
+
+// Returns code of status name, e.g. "INVALID_ARGUMENT"
+func ParseCanonicalCode(name string) (CanonicalCode, bool) {
+	name = strings.ToUpper(name)
+	if name == "CANCELED" {
+		return CodeCanceled, true
+	}
+	for i, codeName := range canonicalCodeNames {
+		if codeName == name {
+			return CanonicalCode(i), true
+		}
+	}
+	return CodeUnknown, false
+}
+
+// Returns canonical code of HTTP status, as Google APIs map them
+func canonicalCodeFromHTTPStatus(status int) CanonicalCode {
+	switch status {
+	case 200:
+		return CodeOK
+	case 400:
+		return CodeInvalidArgument
+	case 401:
+		return CodeUnauthenticated
+	case 403:
+		return CodePermissionDenied
+	case 404:
+		return CodeNotFound
+	case 409:
+		return CodeAborted
+	case 429:
+		return CodeResourceExhausted
+	case 499:
+		return CodeCanceled
+	case 501:
+		return CodeUnimplemented
+	case 503:
+		return CodeUnavailable
+	case 504:
+		return CodeDeadlineExceeded
+	}
+	if status >= 500 {
+		return CodeInternal
+	}
+	return CodeUnknown
+}
+
+// GoogleStatus holds google.rpc.Status code and expanded details
+type GoogleStatus struct {
+	Code CanonicalCode
+
+	ErrorInfo        *GoogleErrorInfo        `json:",omitempty"`
+	RetryDelay       time.Duration           `json:",omitempty"`
+	QuotaViolations  []GoogleQuotaViolation  `json:",omitempty"`
+	LocalizedMessage *GoogleLocalizedMessage `json:",omitempty"`
+	// All details as received, including types not expanded above
+	Details []json.RawMessage `json:",omitempty"`
+}
+
+// google.rpc.ErrorInfo detail
+type GoogleErrorInfo struct {
+	Reason   string            `json:"reason"`
+	Domain   string            `json:"domain"`
+	Metadata map[string]string `json:"metadata"`
+}
+
+// google.rpc.QuotaFailure detail violation
+type GoogleQuotaViolation struct {
+	Subject     string `json:"subject"`
+	Description string `json:"description"`
+}
+
+// google.rpc.LocalizedMessage detail
+type GoogleLocalizedMessage struct {
+	Locale  string `json:"locale"`
+	Message string `json:"message"`
+}
+
+type googleFieldViolation struct {
+	Field       string `json:"field"`
+	Description string `json:"description"`
+}
+
+type googleDetail struct {
+	Type string `json:"@type"`
+
+	// google.rpc.BadRequest
+	FieldViolations []googleFieldViolation `json:"fieldViolations"`
+	// google.rpc.ErrorInfo
+	GoogleErrorInfo
+	// google.rpc.RetryInfo
+	RetryDelay string `json:"retryDelay"`
+	// google.rpc.QuotaFailure
+	Violations []GoogleQuotaViolation `json:"violations"`
+	// google.rpc.LocalizedMessage
+	Locale  string `json:"locale"`
+	Message string `json:"message"`
+}
+
+type googleStatusObject struct {
+	Code    *int              `json:"code"`
+	Message string            `json:"message"`
+	Status  string            `json:"status"`
+	Details []json.RawMessage `json:"details"`
+}
+
+var googleStatusMembers = []string{"code", "message", "status", "details", "errors"}
+
+// Google API error object, google.rpc.Status in JSON mapping
+type googleStatusError struct {
+	Error      googleStatusObject
+	RawMessage json.RawMessage
+}
+
+func (e *googleStatusError) SetRawMessage(m json.RawMessage) {
+	e.RawMessage = m
+}
+
+// Accepts objects having no members but google.rpc.Status ones, with message and
+// either status name or typed details. Plain {"code": N, "message": "..."} objects
+// are too common to be taken for google.rpc.Status
+func (e *googleStatusError) Unmarshal() error {
+	var object map[string]json.RawMessage
+	if err := json.Unmarshal(e.RawMessage, &object); err != nil {
+		return err
+	}
+
+	for key := range object {
+		if !stringInSlice(key, googleStatusMembers) {
+			return errors.New("Unknown google.rpc.Status member " + key)
+		}
+	}
+
+	if err := json.Unmarshal(e.RawMessage, &e.Error); err != nil {
+		return err
+	}
+
+	if _, ok := object["message"]; !ok {
+		return errors.New("google.rpc.Status has no message")
+	}
+
+	if e.Error.Status == "" && !hasTypedDetails(e.Error.Details) {
+		return errors.New("google.rpc.Status has no status name or typed details")
+	}
+
+	return nil
+}
+
+// Reports whether any detail has "@type" member
+func hasTypedDetails(details []json.RawMessage) bool {
+	for _, raw := range details {
+		var detail struct {
+			Type string `json:"@type"`
+		}
+		if json.Unmarshal(raw, &detail) == nil && detail.Type != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// Checks members of decoded value before it is unmarshaled
+func isGoogleStatusShape(n *node) bool {
+	if n.kind != ObjectValue || n.index("message") < 0 {
+		return false
+	}
+	for _, key := range n.keys {
+		if !stringInSlice(key, googleStatusMembers) {
+			return false
+		}
+	}
+
+	if status, _ := n.stringMember("status"); status != "" {
+		return true
+	}
+	if details := n.member("details"); details != nil {
+		for _, detail := range details.items {
+			if t, _ := detail.stringMember("@type"); t != "" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func isHTTPStatus(code int) bool {
+	return code >= 100 && code <= 599
+}
+
+func (e *googleStatusError) TransferTo(ps *ParsedErrors, parent string, path []string) error {
+
+	status := GoogleStatus{Details: e.Error.Details}
+
+	// Google APIs put HTTP status to code, while grpc-gateway puts gRPC code there.
+	// Other codes are application-defined and tell neither
+	code, ok := ParseCanonicalCode(e.Error.Status)
+	if !ok && e.Error.Code != nil {
+		switch c := *e.Error.Code; {
+		case c >= int(CodeOK) && c <= int(CodeUnauthenticated):
+			code, ok = CanonicalCode(c), true
+		case isHTTPStatus(c):
+			code, ok = canonicalCodeFromHTTPStatus(c), true
+		}
+	}
+	status.Code = code
+
+	r := ParsedError{}
+	r.Parent = parent
+	r.Pointer = jsonPointer(path)
+	if ok {
+		r.Code = code.String()
+		r.Status = code.HTTPStatus()
+	}
+	if e.Error.Code != nil && isHTTPStatus(*e.Error.Code) {
+		r.Status = *e.Error.Code
+	}
+
+	if e.Error.Message != "" {
+		r.Messages = append(r.Messages, e.Error.Message)
+		r.MessagePointers = append(r.MessagePointers, jsonPointer(appendPath(path, "message")))
+	}
+
+	for i, raw := range e.Error.Details {
+		var detail googleDetail
+		if err := json.Unmarshal(raw, &detail); err != nil {
+			continue
+		}
+
+		detailPath := append(appendPath(path, "details"), strconv.Itoa(i))
+
+		switch strings.TrimPrefix(detail.Type, "type.googleapis.com/") {
+		case "google.rpc.BadRequest":
+			for j, violation := range detail.FieldViolations {
+				r.addChild(violation.Field, stringValue(violation.Description),
+					jsonPointer(append(detailPath, "fieldViolations", strconv.Itoa(j), "description")))
+			}
+		case "google.rpc.ErrorInfo":
+			errorInfo := detail.GoogleErrorInfo
+			status.ErrorInfo = &errorInfo
+		case "google.rpc.RetryInfo":
+			// Duration in JSON mapping is seconds with "s" suffix, e.g. "1.5s"
+			if delay, err := time.ParseDuration(detail.RetryDelay); err == nil {
+				status.RetryDelay = delay
+			}
+		case "google.rpc.QuotaFailure":
+			status.QuotaViolations = append(status.QuotaViolations, detail.Violations...)
+		case "google.rpc.LocalizedMessage":
+			status.LocalizedMessage = &GoogleLocalizedMessage{Locale: detail.Locale, Message: detail.Message}
+		}
+	}
+
+	r.Google = &status
+	ps.ParsedErrors = append(ps.ParsedErrors, r)
+
+	return nil
+}
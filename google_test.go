@@ -0,0 +1,86 @@
+package go_json_errors_parser
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestCanonicalCode(t *testing.T) {
+	code, ok := ParseCanonicalCode("UNAUTHENTICATED")
+	assert.True(t, ok)
+	assert.Equal(t, CodeUnauthenticated, code)
+	assert.Equal(t, 401, code.HTTPStatus())
+	assert.Equal(t, "UNAUTHENTICATED", code.String())
+
+	_, ok = ParseCanonicalCode("TEAPOT")
+	assert.False(t, ok)
+	assert.Equal(t, "CODE(42)", CanonicalCode(42).String())
+}
+
+func TestGoogleStatusError(t *testing.T) {
+
+	// grpc-gateway puts gRPC code without status name
+	var grpcGateway googleStatusError
+	grpcGateway.RawMessage = json.RawMessage(`{"code": 5, "message": "book not found", "details": [
+		{"@type": "type.googleapis.com/google.rpc.ErrorInfo", "reason": "BOOK_NOT_FOUND"}
+	]}`)
+	assert.NoError(t, grpcGateway.Unmarshal())
+
+	parsedErrors := ParsedErrors{}
+	assert.NoError(t, grpcGateway.TransferTo(&parsedErrors, "", []string{"error"}))
+	assert.Equal(t, "NOT_FOUND", parsedErrors.ParsedErrors[0].Code)
+	assert.Equal(t, 404, parsedErrors.ParsedErrors[0].Status)
+
+	// HTTP status code without status name
+	var httpCode googleStatusError
+	httpCode.RawMessage = json.RawMessage(`{"code": 403, "message": "forbidden", "details": [
+		{"@type": "type.googleapis.com/google.rpc.ErrorInfo", "reason": "ACCESS_DENIED"}
+	]}`)
+	assert.NoError(t, httpCode.Unmarshal())
+
+	parsedErrors = ParsedErrors{}
+	assert.NoError(t, httpCode.TransferTo(&parsedErrors, "", nil))
+	assert.Equal(t, CodePermissionDenied, parsedErrors.ParsedErrors[0].Google.Code)
+	assert.Equal(t, 403, parsedErrors.ParsedErrors[0].Status)
+
+	// fault unmarshal, example11 error has unknown member
+	var unknownMember googleStatusError
+	unknownMember.RawMessage = json.RawMessage(`{"code": 404, "message": "Not Found", "extra-data": false}`)
+	assert.Error(t, unknownMember.Unmarshal())
+
+	// application-defined code is neither canonical code nor HTTP status
+	var appCode googleStatusError
+	appCode.RawMessage = json.RawMessage(`{"code": 1001, "message": "Invalid coupon", "details": [
+		{"@type": "type.googleapis.com/google.rpc.ErrorInfo", "reason": "INVALID_COUPON"}
+	]}`)
+	assert.NoError(t, appCode.Unmarshal())
+
+	parsedErrors = ParsedErrors{}
+	assert.NoError(t, appCode.TransferTo(&parsedErrors, "", nil))
+	assert.Equal(t, "", parsedErrors.ParsedErrors[0].Code)
+	assert.Equal(t, 0, parsedErrors.ParsedErrors[0].Status)
+
+	// fault unmarshal of generic error object without status name or typed details
+	var generic googleStatusError
+	generic.RawMessage = json.RawMessage(`{"code": 403, "message": "forbidden"}`)
+	assert.Error(t, generic.Unmarshal())
+
+	// fault unmarshal without message
+	var noMessage googleStatusError
+	noMessage.RawMessage = json.RawMessage(`{"code": 404}`)
+	assert.Error(t, noMessage.Unmarshal())
+}
+
+func TestParseGenericCodeError(t *testing.T) {
+	errs, err := Parse([]byte(`{"error": {"code": 1001, "message": "Invalid coupon"}}`))
+	assert.NoError(t, err)
+	assert.Nil(t, errs.ParsedErrors[0].Google)
+	assert.NotEqual(t, 1001, errs.ParsedErrors[0].Status)
+	assert.NotEqual(t, CategoryServer, errs.ParsedErrors[0].Category)
+
+	errs, err = Parse([]byte(`{"jsonrpc": "2.0", "error": {"code": -32602, "message": "Invalid params"}, "id": 1}`))
+	assert.NoError(t, err)
+	assert.Nil(t, errs.ParsedErrors[0].Google)
+	assert.False(t, errs.IsRetryable())
+}
@@ -0,0 +1,118 @@
+package go_json_errors_parser
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// GraphQLError holds members of GraphQL response error
+type GraphQLError struct {
+	Path       GraphQLPath
+	Locations  []GraphQLLocation
+	Extensions map[string]json.RawMessage `json:",omitempty"`
+}
+
+// GraphQLLocation points to the query document
+type GraphQLLocation struct {
+	Line   int `json:"line"`
+	Column int `json:"column"`
+}
+
+// Path of the response field the error occurred in, elements are field names (string) or list indices (int)
+type GraphQLPath []interface{}
+
+func (gp GraphQLPath) String() string {
+	var parts []string
+	for _, element := range gp {
+		parts = append(parts, fmt.Sprintf("%v", element))
+	}
+	return strings.Join(parts, ".")
+}
+
+type graphQLErrorObject struct {
+	Message    string                     `json:"message"`
+	Locations  []GraphQLLocation          `json:"locations"`
+	Path       []interface{}              `json:"path"`
+	Extensions map[string]json.RawMessage `json:"extensions"`
+}
+
+var graphQLResponseMembers = []string{"data", "errors", "extensions"}
+var graphQLErrorMembers = []string{"message", "locations", "path", "extensions"}
+
+// Recognizes GraphQL response: object of data, errors and extensions members
+// with errors array of objects having message.
+// Consumes data and errors, so response data is not taken for errors
+func (p *Parser) recognizeGraphQL(item *node, ps *ParsedErrors, parent string, path []string) ([]string, error) {
+
+	for _, key := range item.keys {
+		if !stringInSlice(key, graphQLResponseMembers) {
+			return nil, nil
+		}
+	}
+
+	var objects []map[string]json.RawMessage
+	if !item.unmarshalMember("errors", &objects) || len(objects) == 0 {
+		return nil, nil
+	}
+
+	for _, object := range objects {
+		for key := range object {
+			if !stringInSlice(key, graphQLErrorMembers) {
+				return nil, nil
+			}
+		}
+
+		var message string
+		if raw, ok := object["message"]; !ok || json.Unmarshal(raw, &message) != nil {
+			return nil, nil
+		}
+	}
+
+	var graphQLErrors []graphQLErrorObject
+	if !item.unmarshalMember("errors", &graphQLErrors) {
+		return nil, nil
+	}
+
+	p.traceFormat(path, "graphql")
+
+	for i, object := range graphQLErrors {
+		objectPath := append(appendPath(path, "errors"), strconv.Itoa(i))
+
+		r := ParsedError{}
+		r.Parent = parent
+		r.Pointer = jsonPointer(objectPath)
+		r.Messages = append(r.Messages, object.Message)
+		r.MessagePointers = append(r.MessagePointers, jsonPointer(appendPath(objectPath, "message")))
+
+		if raw, ok := object.Extensions["code"]; ok {
+			var code interface{}
+			if err := json.Unmarshal(raw, &code); err == nil && code != nil {
+				r.Code = fmt.Sprintf("%v", code)
+			}
+		}
+
+		r.GraphQL = &GraphQLError{
+			Locations:  object.Locations,
+			Extensions: object.Extensions,
+		}
+
+		// Path indices are unmarshaled as float64
+		for _, element := range object.Path {
+			if index, ok := element.(float64); ok {
+				element = int(index)
+			}
+			r.GraphQL.Path = append(r.GraphQL.Path, element)
+		}
+
+		r.Extractor = "graphql"
+		ps.ParsedErrors = append(ps.ParsedErrors, r)
+	}
+
+	if !item.member("data").isNull() {
+		ps.Partial = true
+	}
+
+	return []string{"data", "errors"}, nil
+}
@@ -0,0 +1,24 @@
+package go_json_errors_parser
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestRecognizeGraphQL(t *testing.T) {
+
+	// total failure
+	errs, err := Parse([]byte(`{"errors": [{"message": "Unauthorized", "extensions": {"code": 401}}], "data": null}`))
+	assert.NoError(t, err)
+	assert.Equal(t, 1, errs.GetCount())
+	assert.Equal(t, false, errs.Partial)
+	assert.Equal(t, "401", errs.ParsedErrors[0].Code)
+	assert.Equal(t, "/errors/0/message", errs.ParsedErrors[0].MessagePointers[0])
+	assert.Nil(t, errs.ParsedErrors[0].GraphQL.Path)
+
+	// not a graphql response because of other members
+	errs, err = Parse([]byte(`{"errors": [{"message": "Unauthorized"}], "status": 401}`))
+	assert.NoError(t, err)
+	assert.Equal(t, 1, errs.GetCount())
+	assert.Nil(t, errs.ParsedErrors[0].GraphQL)
+}
@@ -0,0 +1,140 @@
+package go_json_errors_parser
+
+import (
+	"encoding/json"
+	"github.com/pkg/errors"
+	"strconv"
+)
+
+// JSONAPIError holds members of JSON:API error object not kept in ParsedError typed fields
+type JSONAPIError struct {
+	ID     string
+	Source JSONAPISource
+	Links  map[string]json.RawMessage `json:",omitempty"`
+	Meta   map[string]json.RawMessage `json:",omitempty"`
+}
+
+// JSONAPISource references the source of JSON:API error
+type JSONAPISource struct {
+	Pointer   string `json:"pointer"`
+	Parameter string `json:"parameter"`
+	Header    string `json:"header"`
+}
+
+type jsonAPIErrorObject struct {
+	ID     string                     `json:"id"`
+	Links  map[string]json.RawMessage `json:"links"`
+	Status interface{}                `json:"status"`
+	Code   string                     `json:"code"`
+	Title  string                     `json:"title"`
+	Detail string                     `json:"detail"`
+	Source JSONAPISource              `json:"source"`
+	Meta   map[string]json.RawMessage `json:"meta"`
+}
+
+var jsonAPIErrorMembers = []string{"id", "links", "status", "code", "title", "detail", "source", "meta"}
+
+// JSON:API errors array, every element becomes its own error
+type jsonAPIError struct {
+	Error      []jsonAPIErrorObject
+	RawMessage json.RawMessage
+}
+
+func (e *jsonAPIError) SetRawMessage(m json.RawMessage) {
+	e.RawMessage = m
+}
+
+// Accepts only non-empty arrays of objects having no members but JSON:API ones,
+// each having title, detail, code or status
+func (e *jsonAPIError) Unmarshal() error {
+	var objects []map[string]json.RawMessage
+	if err := json.Unmarshal(e.RawMessage, &objects); err != nil {
+		return err
+	}
+
+	if len(objects) == 0 {
+		return errors.New("Empty JSON:API errors array")
+	}
+
+	for _, object := range objects {
+		for key := range object {
+			if !stringInSlice(key, jsonAPIErrorMembers) {
+				return errors.New("Unknown JSON:API error member " + key)
+			}
+		}
+
+		_, hasTitle := object["title"]
+		_, hasDetail := object["detail"]
+		_, hasCode := object["code"]
+		_, hasStatus := object["status"]
+		if !(hasTitle || hasDetail || hasCode || hasStatus) {
+			return errors.New("JSON:API error has no title, detail, code or status")
+		}
+	}
+
+	return json.Unmarshal(e.RawMessage, &e.Error)
+}
+
+// Checks members of decoded value before it is unmarshaled
+func isJSONAPIShape(n *node) bool {
+	if n.kind != ArrayValue || len(n.items) == 0 {
+		return false
+	}
+	for _, item := range n.items {
+		if item.kind != ObjectValue {
+			return false
+		}
+		for _, key := range item.keys {
+			if !stringInSlice(key, jsonAPIErrorMembers) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func (e *jsonAPIError) TransferTo(ps *ParsedErrors, parent string, path []string) error {
+
+	for i, object := range e.Error {
+		objectPath := appendPath(path, strconv.Itoa(i))
+
+		r := ParsedError{}
+		r.Parent = parent
+		r.Pointer = jsonPointer(objectPath)
+		r.Code = object.Code
+		r.Title = object.Title
+
+		switch status := object.Status.(type) {
+		case string:
+			r.Status, _ = strconv.Atoi(status)
+		case float64:
+			r.Status = int(status)
+		}
+
+		switch {
+		case object.Detail != "":
+			r.Messages = append(r.Messages, object.Detail)
+			r.MessagePointers = append(r.MessagePointers, jsonPointer(appendPath(objectPath, "detail")))
+		case object.Title != "":
+			r.Messages = append(r.Messages, object.Title)
+			r.MessagePointers = append(r.MessagePointers, jsonPointer(appendPath(objectPath, "title")))
+		}
+
+		if object.Source.Pointer != "" {
+			r.Field = object.Source.Pointer
+		} else {
+			r.Field = object.Source.Parameter
+		}
+
+		r.JSONAPI = &JSONAPIError{
+			ID:     object.ID,
+			Source: object.Source,
+			Links:  object.Links,
+			Meta:   object.Meta,
+		}
+
+		ps.ParsedErrors = append(ps.ParsedErrors, r)
+	}
+
+	return nil
+}
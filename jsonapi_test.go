@@ -0,0 +1,34 @@
+package go_json_errors_parser
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestJSONAPIError(t *testing.T) {
+
+	// success unmarshal
+	var unmarshaledError jsonAPIError
+	unmarshaledError.RawMessage = json.RawMessage(`[{"code": "a", "detail": "first"}, {"status": 404, "title": "second"}]`)
+	assert.NoError(t, unmarshaledError.Unmarshal())
+
+	// transfer to parsed errors struct, one error per element
+	parsedErrors := ParsedErrors{}
+	assert.NoError(t, unmarshaledError.TransferTo(&parsedErrors, "TestParent", []string{"errors"}))
+	assert.Equal(t, 2, parsedErrors.GetCount())
+	assert.Equal(t, "first", parsedErrors.ParsedErrors[0].Messages[0])
+	assert.Equal(t, "/errors/0/detail", parsedErrors.ParsedErrors[0].MessagePointers[0])
+	assert.Equal(t, 404, parsedErrors.ParsedErrors[1].Status)
+	assert.Equal(t, "TestParent", parsedErrors.ParsedErrors[1].Parent)
+
+	// fault unmarshal on unknown members
+	var unknownMember jsonAPIError
+	unknownMember.RawMessage = json.RawMessage(`[{"secure": false, "name": "ADF", "title": "123"}]`)
+	assert.Error(t, unknownMember.Unmarshal())
+
+	// fault unmarshal on objects without error members
+	var noErrorMembers jsonAPIError
+	noErrorMembers.RawMessage = json.RawMessage(`[{"id": "1"}]`)
+	assert.Error(t, noErrorMembers.Unmarshal())
+}
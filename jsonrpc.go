@@ -0,0 +1,141 @@
+package go_json_errors_parser
+
+import (
+	"encoding/json"
+	"strconv"
+)
+
+// Kind of JSON-RPC 2.0 error code
+type JSONRPCErrorKind int
+
+const (
+	// Code outside of the reserved range, defined by application
+	RPCApplicationError JSONRPCErrorKind = iota
+	RPCParseError
+	RPCInvalidRequest
+	RPCMethodNotFound
+	RPCInvalidParams
+	RPCInternalError
+	// Code from -32000 to -32099, reserved for implementation-defined server errors
+	RPCServerError
+	// Other code from -32768 to -32000 range reserved by the specification
+	RPCReservedError
+)
+
+var jsonRPCErrorKindNames = []string{
+	"application error",
+	"parse error",
+	"invalid request",
+	"method not found",
+	"invalid params",
+	"internal error",
+	"server error",
+	"reserved error",
+}
+
+func (k JSONRPCErrorKind) String() string {
+	if k < 0 || int(k) >= len(jsonRPCErrorKindNames) {
+		return "unknown error"
+	}
+	return jsonRPCErrorKindNames[k]
+}
+
+// Returns kind of JSON-RPC 2.0 error code
+func JSONRPCErrorKindOf(code int) JSONRPCErrorKind {
+	switch {
+	case code == -32700:
+		return RPCParseError
+	case code == -32600:
+		return RPCInvalidRequest
+	case code == -32601:
+		return RPCMethodNotFound
+	case code == -32602:
+		return RPCInvalidParams
+	case code == -32603:
+		return RPCInternalError
+	case code >= -32099 && code <= -32000:
+		return RPCServerError
+	case code >= -32768 && code <= -32000:
+		return RPCReservedError
+	}
+	return RPCApplicationError
+}
+
+// JSONRPCError holds JSON-RPC 2.0 error object members and id of the request it answers
+type JSONRPCError struct {
+	// Request id as received: number, string or null
+	ID   json.RawMessage
+	Code int
+	Kind JSONRPCErrorKind
+	Data json.RawMessage `json:",omitempty"`
+}
+
+type jsonRPCErrorObject struct {
+	Code    *int            `json:"code"`
+	Message string          `json:"message"`
+	Data    json.RawMessage `json:"data"`
+}
+
+var jsonRPCResponseMembers = []string{"jsonrpc", "id", "error", "result"}
+
+// Enables recognition of JSON-RPC 2.0 responses, single or batched in array.
+// Errors found in error data are linked to the request id as well
+func WithJSONRPC() Option {
+	return func(p *Parser) {
+		p.recognizers = append(p.recognizers, (*Parser).recognizeJSONRPC)
+	}
+}
+
+// Recognizes JSON-RPC 2.0 response with error object.
+// Error data is walked for errors as a whole value
+func (p *Parser) recognizeJSONRPC(item *node, ps *ParsedErrors, parent string, path []string) ([]string, error) {
+
+	if version, _ := item.stringMember("jsonrpc"); version != "2.0" {
+		return nil, nil
+	}
+
+	var object jsonRPCErrorObject
+	if !item.unmarshalMember("error", &object) || object.Code == nil {
+		return nil, nil
+	}
+
+	p.traceFormat(path, "jsonrpc")
+
+	rpcError := &JSONRPCError{
+		ID:   json.RawMessage("null"),
+		Code: *object.Code,
+		Kind: JSONRPCErrorKindOf(*object.Code),
+		Data: object.Data,
+	}
+	if id := item.member("id"); !id.isNull() {
+		rpcError.ID = id.raw()
+	}
+
+	errorPath := appendPath(path, "error")
+
+	r := ParsedError{}
+	r.Parent = parent
+	r.Pointer = jsonPointer(errorPath)
+	r.Code = strconv.Itoa(rpcError.Code)
+	r.Messages = append(r.Messages, object.Message)
+	r.MessagePointers = append(r.MessagePointers, jsonPointer(appendPath(errorPath, "message")))
+	r.RPC = rpcError
+	r.Extractor = "jsonrpc"
+	ps.ParsedErrors = append(ps.ParsedErrors, r)
+
+	if data := item.member("error").member("data"); data != nil {
+		n := len(ps.ParsedErrors)
+
+		if err := p.walkValue(data, ps, "data", appendPath(errorPath, "data")); err != nil {
+			return nil, err
+		}
+
+		for i := n; i < len(ps.ParsedErrors); i++ {
+			if ps.ParsedErrors[i].RPC == nil {
+				ps.ParsedErrors[i].RPC = rpcError
+			}
+		}
+	}
+
+	return jsonRPCResponseMembers, nil
+}
@@ -0,0 +1,45 @@
+package go_json_errors_parser
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestJSONRPCErrorKindOf(t *testing.T) {
+	assert.Equal(t, RPCMethodNotFound, JSONRPCErrorKindOf(-32601))
+	assert.Equal(t, RPCInternalError, JSONRPCErrorKindOf(-32603))
+	assert.Equal(t, RPCServerError, JSONRPCErrorKindOf(-32050))
+	assert.Equal(t, RPCReservedError, JSONRPCErrorKindOf(-32500))
+	assert.Equal(t, RPCApplicationError, JSONRPCErrorKindOf(42))
+	assert.Equal(t, "invalid params", RPCInvalidParams.String())
+}
+
+func TestRecognizeJSONRPC(t *testing.T) {
+	doc := []byte(`{"jsonrpc": "2.0", "error": {"code": 10, "message": "Insufficient funds", "data": "balance is 0"}, "id": "abc"}`)
+
+	errs, err := NewParser(WithJSONRPC()).Parse(doc)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, errs.GetCount())
+
+	for _, parsedError := range errs.ParsedErrors {
+		assert.Equal(t, `"abc"`, string(parsedError.RPC.ID))
+		assert.Equal(t, RPCApplicationError, parsedError.RPC.Kind)
+	}
+
+	// application codes are not HTTP statuses
+	for _, code := range []string{"404", "500"} {
+		errs, err = NewParser(WithJSONRPC()).Parse([]byte(`{"jsonrpc": "2.0", "error": {"code": ` + code + `, "message": "Out of stock"}, "id": 1}`))
+		assert.NoError(t, err)
+		assert.Equal(t, CategoryUnknown, errs.ParsedErrors[0].Category, code)
+		assert.False(t, errors.Is(errs, ErrServer))
+		assert.False(t, errors.Is(errs, ErrNotFound))
+	}
+
+	// not recognized without JSON-RPC mode
+	errs, err = Parse(doc)
+	assert.NoError(t, err)
+	for _, parsedError := range errs.ParsedErrors {
+		assert.Nil(t, parsedError.RPC)
+	}
+}
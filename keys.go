@@ -0,0 +1,131 @@
+package go_json_errors_parser
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Kind of key pattern
+type PatternKind int
+
+const (
+	// Regular expression, matches anywhere in the key unless anchored
+	PatternRegexp PatternKind = iota
+	// Shell-like glob, '*' matches any sequence and '?' matches any single character
+	PatternGlob
+	// Exact key name
+	PatternExact
+)
+
+// KeyPattern describes keys holding errors
+type KeyPattern struct {
+	Kind          PatternKind
+	Pattern       string
+	CaseSensitive bool
+}
+
+// Returns case-insensitive regexp pattern
+func Regexp(pattern string) KeyPattern {
+	return KeyPattern{Kind: PatternRegexp, Pattern: pattern}
+}
+
+// Returns case-insensitive glob pattern
+func Glob(pattern string) KeyPattern {
+	return KeyPattern{Kind: PatternGlob, Pattern: pattern}
+}
+
+// Returns case-insensitive exact key pattern
+func Exact(key string) KeyPattern {
+	return KeyPattern{Kind: PatternExact, Pattern: key}
+}
+
+// Returns copy of pattern matching keys case-sensitively
+func (kp KeyPattern) WithCaseSensitive() KeyPattern {
+	kp.CaseSensitive = true
+	return kp
+}
+
+func (kp KeyPattern) compile() (*regexp.Regexp, error) {
+	var expr string
+
+	switch kp.Kind {
+	case PatternGlob:
+		expr = "^" + strings.NewReplacer(`\*`, ".*", `\?`, ".").Replace(regexp.QuoteMeta(kp.Pattern)) + "$"
+	case PatternExact:
+		expr = "^" + regexp.QuoteMeta(kp.Pattern) + "$"
+	default:
+		expr = kp.Pattern
+	}
+
+	if !kp.CaseSensitive {
+		expr = "(?i)" + expr
+	}
+
+	return regexp.Compile(expr)
+}
+
+// Returns patterns used by default parser: any key containing "error", case-insensitive
+func DefaultKeyPatterns() []KeyPattern {
+	return []KeyPattern{Regexp(`(.+|.?)(error)(.+|.?)`)}
+}
+
+// KeyMatcher decides whether key holds errors
+type KeyMatcher struct {
+	include []*regexp.Regexp
+	exclude []*regexp.Regexp
+}
+
+// Returns matcher accepting keys matched by any of include patterns and none of exclude patterns
+func NewKeyMatcher(include []KeyPattern, exclude []KeyPattern) (*KeyMatcher, error) {
+	m := &KeyMatcher{}
+
+	for _, kp := range include {
+		re, err := kp.compile()
+		if err != nil {
+			return nil, err
+		}
+		m.include = append(m.include, re)
+	}
+
+	for _, kp := range exclude {
+		re, err := kp.compile()
+		if err != nil {
+			return nil, err
+		}
+		m.exclude = append(m.exclude, re)
+	}
+
+	return m, nil
+}
+
+// Returns matcher built from default patterns
+func DefaultKeyMatcher() *KeyMatcher {
+	m, err := NewKeyMatcher(DefaultKeyPatterns(), nil)
+	if err != nil {
+		panic(err)
+	}
+	return m
+}
+
+func (m *KeyMatcher) Match(key string) bool {
+	for _, re := range m.exclude {
+		if re.MatchString(key) {
+			return false
+		}
+	}
+
+	for _, re := range m.include {
+		if re.MatchString(key) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Sets matcher of keys holding errors
+func WithKeyMatcher(m *KeyMatcher) Option {
+	return func(p *Parser) {
+		p.keys = m
+	}
+}
@@ -0,0 +1,48 @@
+package go_json_errors_parser
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestKeyMatcher(t *testing.T) {
+	m, err := NewKeyMatcher(
+		[]KeyPattern{Exact("faults"), Glob("*problem?"), Regexp(`^violations$`).WithCaseSensitive()},
+		[]KeyPattern{Exact("faultsCount")},
+	)
+	assert.NoError(t, err)
+
+	assert.True(t, m.Match("faults"))
+	assert.True(t, m.Match("FAULTS"))
+	assert.False(t, m.Match("faultsCount"))
+	assert.True(t, m.Match("fieldProblems"))
+	assert.False(t, m.Match("problem"))
+	assert.True(t, m.Match("violations"))
+	assert.False(t, m.Match("Violations"))
+	assert.False(t, m.Match("errors"))
+
+	_, err = NewKeyMatcher([]KeyPattern{Regexp(`(`)}, nil)
+	assert.Error(t, err)
+}
+
+func TestDefaultKeyMatcher(t *testing.T) {
+	m := DefaultKeyMatcher()
+
+	assert.True(t, m.Match("errors"))
+	assert.True(t, m.Match("errorReport"))
+	assert.True(t, m.Match("mirror_terror"))
+	assert.False(t, m.Match("message"))
+}
+
+func TestParserWithKeyMatcher(t *testing.T) {
+	doc := []byte(`{"errorCount": 1, "issues": ["Name is required"], "data": {"warnings": {"age": "too young"}}}`)
+
+	m, err := NewKeyMatcher([]KeyPattern{Exact("issues"), Exact("warnings")}, []KeyPattern{Glob("*count")})
+	assert.NoError(t, err)
+
+	errs, err := NewParser(WithKeyMatcher(m)).Parse(doc)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, errs.GetCount())
+	assert.Equal(t, "Name is required", errs.ParsedErrors[0].Messages[0])
+	assert.Equal(t, "too young", errs.ParsedErrors[1].Children["age"][0])
+}
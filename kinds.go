@@ -0,0 +1,63 @@
+package go_json_errors_parser
+
+import (
+	"errors"
+	"strings"
+)
+
+// Sentinel kinds of parsed errors, matched by errors.Is
+var (
+	ErrValidation   = errors.New("jerrparser: validation error")
+	ErrUnauthorized = errors.New("jerrparser: unauthorized")
+	ErrForbidden    = errors.New("jerrparser: forbidden")
+	ErrNotFound     = errors.New("jerrparser: not found")
+	ErrConflict     = errors.New("jerrparser: conflict")
+	ErrRateLimited  = errors.New("jerrparser: rate limited")
+	ErrServer       = errors.New("jerrparser: server error")
+)
+
+// Sentinel kind of each category, auth errors are ErrUnauthorized unless access is denied
+var categoryKinds = map[Category]error{
+	CategoryAuth:       ErrUnauthorized,
+	CategoryValidation: ErrValidation,
+	CategoryNotFound:   ErrNotFound,
+	CategoryConflict:   ErrConflict,
+	CategoryRateLimit:  ErrRateLimited,
+	CategoryServer:     ErrServer,
+}
+
+// Returns sentinel kind of category, nil for unknown category.
+// Denied access, like 403 status or PERMISSION_DENIED code, makes auth error ErrForbidden
+func (c Category) kind(denied bool) error {
+	if c == CategoryAuth && denied {
+		return ErrForbidden
+	}
+	return categoryKinds[c]
+}
+
+// Reports whether status or code tells access is denied rather than not authenticated
+func isAccessDenied(status int, code string) bool {
+	switch strings.ToUpper(code) {
+	case "403", "PERMISSION_DENIED", "FORBIDDEN":
+		return true
+	}
+	return status == 403
+}
+
+// Reports whether error is of sentinel kind target. The kind follows Category,
+// so errors not categorized by parser have no kind
+func (e ParsedError) Is(target error) bool {
+	kind := e.Category.kind(isAccessDenied(e.Status, e.Code))
+	return kind != nil && kind == target
+}
+
+// Reports whether response the errors were parsed from has status of sentinel kind target.
+// Kinds of every parsed error are matched by errors.Is through Unwrap
+func (pe *ParsedErrors) Is(target error) bool {
+	if pe.Response == nil {
+		return false
+	}
+
+	kind := categoryOfStatus(pe.Response.StatusCode).kind(pe.Response.StatusCode == 403)
+	return kind != nil && kind == target
+}
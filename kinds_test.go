@@ -0,0 +1,87 @@
+package go_json_errors_parser
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestParsedErrorsIs(t *testing.T) {
+
+	file, e := ioutil.ReadFile("tests/example16.json")
+	assert.NoError(t, e)
+
+	errs, err := Parse(file)
+	assert.NoError(t, err)
+
+	assert.True(t, errors.Is(errs, ErrValidation))
+	assert.False(t, errors.Is(errs, ErrNotFound))
+
+	// joined with other errors
+	joined := errors.Join(errors.New("request failed"), errs)
+	assert.True(t, errors.Is(joined, ErrValidation))
+
+	var parsedError ParsedError
+	assert.True(t, errors.As(joined, &parsedError))
+	assert.Equal(t, "INVALID_ARGUMENT", parsedError.Code)
+
+	// rendered errors keep parsed error
+	assert.True(t, errors.Is(errs.GetErrors()[0], ErrValidation))
+}
+
+func TestParsedErrorKinds(t *testing.T) {
+	// kind follows category, keywords included
+	errs := ParseErrors(`{"error": "Unauthorized"}`)
+	assert.Equal(t, CategoryAuth, errs.ParsedErrors[0].Category)
+	assert.True(t, errors.Is(errs, ErrUnauthorized))
+	assert.False(t, errors.Is(errs, ErrForbidden))
+
+	errs = ParseErrors(`{"error": "Access denied", "status": 403}`)
+	assert.True(t, errors.Is(errs, ErrForbidden))
+	assert.False(t, errors.Is(errs, ErrUnauthorized))
+
+	errs = ParseErrors(`{"errors": [{"message": "No such user", "extensions": {"code": "NOT_FOUND"}}]}`)
+	assert.True(t, errors.Is(errs, ErrNotFound))
+
+	rpcErrs, err := NewParser(WithJSONRPC()).Parse([]byte(`{"jsonrpc": "2.0", "error": {"code": -32603, "message": "Internal error"}, "id": 1}`))
+	assert.NoError(t, err)
+	assert.True(t, errors.Is(rpcErrs, ErrServer))
+
+	errs = ParseErrors(`{"errors": {"name": ["required"]}}`)
+	assert.True(t, errors.Is(errs, ErrValidation))
+
+	assert.True(t, errors.Is(ParsedError{Category: CategoryRateLimit}, ErrRateLimited))
+	// not categorized error has no kind
+	assert.False(t, errors.Is(ParsedError{Status: 401}, ErrUnauthorized))
+}
+
+func TestParsedErrorsError(t *testing.T) {
+	errs := ParseErrors(`{"error": "Unauthorized", "data": {"errors": {"name": ["required", "too short"]}}}`)
+
+	assert.Equal(t, "[] Unauthorized; [data][name] required; [data][name] too short", errs.Error())
+	assert.Error(t, errs.Err())
+
+	errs = ParseErrors(`{}`)
+	assert.NoError(t, errs.Err())
+}
+
+func TestResponseErrorIs(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"error": "No such book"}`))
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: NewTransport(nil)}
+	_, err := client.Get(server.URL)
+
+	assert.True(t, errors.Is(err, ErrNotFound))
+
+	var parsedErrors *ParsedErrors
+	assert.True(t, errors.As(err, &parsedErrors))
+	assert.Equal(t, "No such book", parsedErrors.ParsedErrors[0].Messages[0])
+}
@@ -0,0 +1,74 @@
+package go_json_errors_parser
+
+import "io"
+
+// Limits of parsed input, zero means no limit.
+// Exceeding a limit stops parsing with *LimitExceededError,
+// errors found in input read so far are returned along with it
+type Limits struct {
+	// Bytes read from input
+	MaxBytes int64
+	// Nesting depth of objects and arrays
+	MaxDepth int
+	// Number of parsed errors, the first MaxErrors errors are returned
+	MaxErrors int
+	// Length of strings and object keys in bytes
+	MaxStringLength int
+}
+
+// Sets limits of parsed input, applied by Parse, ParseReader and ParseResponse
+func WithLimits(limits Limits) Option {
+	return func(p *Parser) {
+		p.limits = limits
+	}
+}
+
+// Parses json read from r, with default parser if no option is given.
+// Input is decoded as it is read, without buffering it as a whole
+func ParseReader(r io.Reader, opts ...Option) (*ParsedErrors, error) {
+	p := defaultParser
+	if len(opts) > 0 {
+		p = NewParser(opts...)
+	}
+	return p.ParseReader(r)
+}
+
+// Parses json read from r, like Parse.
+// Values of children keep compact json instead of the source one
+func (p *Parser) ParseReader(r io.Reader) (*ParsedErrors, error) {
+	return p.parse(newNodeReader(r, nil, p.limits))
+}
+
+// Drops errors beyond limit, returns *LimitExceededError if there were any
+func (p *Parser) limitErrors(ps *ParsedErrors) error {
+	if max := p.limits.MaxErrors; max > 0 && len(ps.ParsedErrors) > max {
+		ps.ParsedErrors = ps.ParsedErrors[:max]
+		return &LimitExceededError{Limit: "errors", Max: int64(max)}
+	}
+	return nil
+}
+
+// Fails reading once more than max bytes are read
+type limitReader struct {
+	r    io.Reader
+	max  int64
+	read int64
+}
+
+func (l *limitReader) Read(p []byte) (int, error) {
+	if l.read >= l.max {
+		// Anything left beyond the limit
+		var b [1]byte
+		if n, err := l.r.Read(b[:]); n == 0 {
+			return 0, err
+		}
+		return 0, &LimitExceededError{Limit: "bytes", Max: l.max}
+	}
+
+	if int64(len(p)) > l.max-l.read {
+		p = p[:l.max-l.read]
+	}
+	n, err := l.r.Read(p)
+	l.read += int64(n)
+	return n, err
+}
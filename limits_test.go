@@ -0,0 +1,87 @@
+package go_json_errors_parser
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"strings"
+	"testing"
+)
+
+func TestParseReader(t *testing.T) {
+	file, e := ioutil.ReadFile("tests/example1.json")
+	assert.NoError(t, e)
+
+	expected, err := Parse(file)
+	assert.NoError(t, err)
+
+	errs, err := ParseReader(bytes.NewReader(file))
+	assert.NoError(t, err)
+	assert.Equal(t, expected.GetErrors(), errs.GetErrors())
+}
+
+func TestParseReaderSyntaxError(t *testing.T) {
+	errs, err := ParseReader(strings.NewReader(`{"error": "Unauthorized",}`))
+
+	assert.False(t, errs.IsErrors())
+	assert.IsType(t, &SyntaxError{}, err)
+}
+
+func TestParseReaderMaxBytes(t *testing.T) {
+	data := `{"errors": ["first", "second"], "message": "third error"}`
+	limits := Limits{MaxBytes: 32}
+
+	errs, err := ParseReader(strings.NewReader(data), WithLimits(limits))
+
+	limitErr, ok := err.(*LimitExceededError)
+	assert.True(t, ok)
+	assert.Equal(t, "bytes", limitErr.Limit)
+	assert.Equal(t, int64(32), limitErr.Max)
+
+	// Errors read before the limit are kept
+	assert.Equal(t, 1, errs.GetCount())
+	assert.Equal(t, []string{"first", "second"}, errs.ParsedErrors[0].Messages)
+
+	_, err = ParseReader(strings.NewReader(data), WithLimits(Limits{MaxBytes: int64(len(data))}))
+	assert.NoError(t, err)
+}
+
+func TestParseMaxDepth(t *testing.T) {
+	parser := NewParser(WithLimits(Limits{MaxDepth: 50}))
+
+	errs, err := parser.Parse(nestedDocument(100))
+
+	limitErr, ok := err.(*LimitExceededError)
+	assert.True(t, ok)
+	assert.Equal(t, "depth", limitErr.Limit)
+	assert.False(t, errs.IsErrors())
+
+	_, err = parser.Parse(nestedDocument(40))
+	assert.NoError(t, err)
+}
+
+func TestParseMaxErrors(t *testing.T) {
+	parser := NewParser(WithLimits(Limits{MaxErrors: 3}))
+
+	errs, err := parser.Parse(bulkResponse(100))
+
+	limitErr, ok := err.(*LimitExceededError)
+	assert.True(t, ok)
+	assert.Equal(t, "errors", limitErr.Limit)
+	assert.Equal(t, 3, errs.GetCount())
+	assert.Equal(t, "/items/0/errors", errs.ParsedErrors[0].Pointer)
+}
+
+func TestParseMaxStringLength(t *testing.T) {
+	parser := NewParser(WithLimits(Limits{MaxStringLength: 10}))
+
+	errs, err := parser.Parse([]byte(`{"errors": ["short", "much longer message"]}`))
+
+	limitErr, ok := err.(*LimitExceededError)
+	assert.True(t, ok)
+	assert.Equal(t, "string length", limitErr.Limit)
+	assert.Equal(t, []string{"short"}, errs.ParsedErrors[0].Messages)
+
+	_, err = parser.Parse([]byte(`{"much longer key": "error"}`))
+	assert.IsType(t, &LimitExceededError{}, err)
+}
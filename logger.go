@@ -0,0 +1,29 @@
+package go_json_errors_parser
+
+// Logger receives trace events of parser as message and key-value pairs,
+// *slog.Logger satisfies it
+type Logger interface {
+	Debug(msg string, args ...interface{})
+}
+
+// Sets logger receiving trace events with key path, extractor name and outcome.
+// Parser doesn't log by default
+func WithLogger(l Logger) Option {
+	return func(p *Parser) {
+		p.logger = l
+	}
+}
+
+// Logs outcome of walking object key
+func (p *Parser) traceKey(path []string, outcome string) {
+	if p.logger != nil {
+		p.logger.Debug("jerrparser: key", "path", jsonPointer(path), "outcome", outcome)
+	}
+}
+
+// Logs format recognized in object
+func (p *Parser) traceFormat(path []string, format string) {
+	if p.logger != nil {
+		p.logger.Debug("jerrparser: recognize", "path", jsonPointer(path), "format", format)
+	}
+}
@@ -0,0 +1,46 @@
+package go_json_errors_parser
+
+import (
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"strings"
+	"testing"
+)
+
+// Records events as "msg key=value ..." lines
+type recordingLogger struct {
+	events []string
+}
+
+func (l *recordingLogger) Debug(msg string, args ...interface{}) {
+	event := msg
+	for i := 0; i+1 < len(args); i += 2 {
+		event += fmt.Sprintf(" %v=%v", args[i], args[i+1])
+	}
+	l.events = append(l.events, event)
+}
+
+func TestWithLogger(t *testing.T) {
+	file, e := ioutil.ReadFile("tests/example3.json")
+	assert.NoError(t, e)
+
+	logger := &recordingLogger{}
+	errs, err := NewParser(WithLogger(logger)).Parse(file)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, errs.GetCount())
+
+	events := strings.Join(logger.events, "\n")
+	assert.Contains(t, events, "jerrparser: key path=/message outcome=error value")
+	assert.Contains(t, events, "jerrparser: key path=/data outcome=descend")
+	assert.Contains(t, events, "jerrparser: key path=/data/errors outcome=error key")
+	assert.Contains(t, events, "jerrparser: extract path=/data/errors extractor=mapStringSliceInterfaceError outcome=extracted errors=1")
+	assert.Equal(t, "jerrparser: parsed errors=2 error=<nil>", logger.events[len(logger.events)-1])
+}
+
+func TestWithLoggerFormats(t *testing.T) {
+	logger := &recordingLogger{}
+	_, err := NewParser(WithLogger(logger)).Parse([]byte(`{"type": "about:blank", "title": "Not Found", "status": 404}`))
+	assert.NoError(t, err)
+	assert.Contains(t, logger.events, "jerrparser: recognize path= format=problem")
+}
@@ -0,0 +1,470 @@
+package go_json_errors_parser
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"unicode/utf8"
+)
+
+// Json value decoded once from document tokens, so its shape is known
+// without unmarshaling it again for every extractor
+type node struct {
+	kind ValueKind
+	// String value, or number as written in document
+	text    string
+	boolean bool
+	// Object keys in document order and their values, duplicated keys are taken once with the last value
+	keys    []string
+	members []*node
+	items   []*node
+
+	// Source json of the value, or compact json encoded on demand
+	encoded json.RawMessage
+}
+
+var errUnexpectedEnd = errors.New("unexpected end of JSON input")
+
+// Reads nodes from decoder tokens. When the whole document is in memory,
+// nodes keep their source json instead of encoding it again
+type nodeReader struct {
+	decoder *json.Decoder
+	data    []byte
+	limits  Limits
+	depth   int
+
+	// Checked every contextCheckInterval values read, if set
+	ctx   context.Context
+	count int
+}
+
+func newNodeReader(r io.Reader, data []byte, limits Limits) *nodeReader {
+	if limits.MaxBytes > 0 {
+		r = &limitReader{r: r, max: limits.MaxBytes}
+	}
+
+	decoder := json.NewDecoder(r)
+	decoder.UseNumber()
+	return &nodeReader{decoder: decoder, data: data, limits: limits}
+}
+
+// Decodes single json value, trailing data is an error
+func decodeNode(data []byte) (*node, error) {
+	return newNodeReader(bytes.NewReader(data), data, Limits{}).decode()
+}
+
+// Decodes document value. When a limit is exceeded or context is done,
+// returns value read so far along with the error
+func (r *nodeReader) decode() (*node, error) {
+	n, err := r.readDocument()
+
+	switch err.(type) {
+	case nil, *LimitExceededError, *contextError:
+	default:
+		n = nil
+	}
+
+	if _, ok := err.(*SyntaxError); ok {
+		// Decoder reports offset of the token, while json.Unmarshal reports
+		// offset of the failed byte, kept by previous versions
+		var v json.RawMessage
+		if r.data == nil {
+			return nil, err
+		}
+		if e, ok := json.Unmarshal(r.data, &v).(*json.SyntaxError); ok {
+			return nil, &SyntaxError{Offset: e.Offset, Err: e}
+		}
+		return nil, err
+	}
+
+	return n, err
+}
+
+// Reads document value, trailing data is an error
+func (r *nodeReader) readDocument() (*node, error) {
+	n, err := r.readNode()
+	if err != nil {
+		return n, err
+	}
+
+	if _, err := r.decoder.Token(); err != io.EOF {
+		if err == nil {
+			err = errors.New("invalid character after top-level value")
+		}
+		return n, r.error(err)
+	}
+
+	return n, nil
+}
+
+// Reads value starting at the next token.
+// On failure returns object or array read so far, if any
+func (r *nodeReader) readNode() (*node, error) {
+	if r.ctx != nil {
+		err := checkContext(r.ctx, r.count)
+		r.count++
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	start := r.decoder.InputOffset()
+
+	token, err := r.decoder.Token()
+	if err != nil {
+		return nil, r.error(err)
+	}
+
+	var n *node
+	switch value := token.(type) {
+	case json.Delim:
+		if value == '{' {
+			n, err = r.readObject()
+		} else {
+			n, err = r.readArray()
+		}
+		if err != nil {
+			return n, err
+		}
+	case string:
+		if err := r.checkString(value); err != nil {
+			return nil, err
+		}
+		n = &node{kind: StringValue, text: value}
+	case json.Number:
+		n = &node{kind: NumberValue, text: string(value)}
+	case bool:
+		n = &node{kind: BoolValue, boolean: value}
+	default:
+		n = &node{kind: NullValue}
+	}
+
+	if r.data != nil {
+		n.encoded = r.source(start, r.decoder.InputOffset())
+	}
+
+	return n, nil
+}
+
+// Returns source of value read between offsets, which may start with separators
+func (r *nodeReader) source(start, end int64) json.RawMessage {
+	for start < end {
+		switch r.data[start] {
+		case ' ', '\t', '\n', '\r', ',', ':':
+			start++
+			continue
+		}
+		break
+	}
+	return r.data[start:end:end]
+}
+
+func (r *nodeReader) checkString(s string) error {
+	if max := r.limits.MaxStringLength; max > 0 && len(s) > max {
+		return &LimitExceededError{Limit: "string length", Max: int64(max)}
+	}
+	return nil
+}
+
+// Counts nesting of objects and arrays entered
+func (r *nodeReader) enter() error {
+	r.depth++
+	if max := r.limits.MaxDepth; max > 0 && r.depth > max {
+		return &LimitExceededError{Limit: "depth", Max: int64(max)}
+	}
+	return nil
+}
+
+// Objects with more keys find duplicated keys by index instead of scanning
+const objectIndexSize = 16
+
+func (r *nodeReader) readObject() (*node, error) {
+	if err := r.enter(); err != nil {
+		return nil, err
+	}
+	defer func() { r.depth-- }()
+
+	n := &node{kind: ObjectValue}
+	var index map[string]int
+
+	for r.decoder.More() {
+		token, err := r.decoder.Token()
+		if err != nil {
+			return n, r.error(err)
+		}
+		key := token.(string)
+		if err := r.checkString(key); err != nil {
+			return n, err
+		}
+
+		member, err := r.readNode()
+		if err != nil {
+			if member != nil {
+				n.keys = append(n.keys, key)
+				n.members = append(n.members, member)
+			}
+			return n, err
+		}
+
+		i, ok := -1, false
+		if index != nil {
+			i, ok = index[key]
+		} else if i = n.index(key); i >= 0 {
+			ok = true
+		}
+		if ok {
+			n.members[i] = member
+			continue
+		}
+
+		n.keys = append(n.keys, key)
+		n.members = append(n.members, member)
+
+		if index != nil {
+			index[key] = len(n.keys) - 1
+		} else if len(n.keys) == objectIndexSize {
+			index = make(map[string]int, 2*objectIndexSize)
+			for i, k := range n.keys {
+				index[k] = i
+			}
+		}
+	}
+
+	// Closing brace
+	if _, err := r.decoder.Token(); err != nil {
+		return n, r.error(err)
+	}
+
+	return n, nil
+}
+
+func (r *nodeReader) readArray() (*node, error) {
+	if err := r.enter(); err != nil {
+		return nil, err
+	}
+	defer func() { r.depth-- }()
+
+	n := &node{kind: ArrayValue}
+
+	for r.decoder.More() {
+		item, err := r.readNode()
+		if item != nil {
+			n.items = append(n.items, item)
+		}
+		if err != nil {
+			return n, err
+		}
+	}
+
+	// Closing bracket
+	if _, err := r.decoder.Token(); err != nil {
+		return n, r.error(err)
+	}
+
+	return n, nil
+}
+
+// Converts decoder error to SyntaxError at the decoder offset
+func (r *nodeReader) error(err error) error {
+	switch e := err.(type) {
+	case *json.SyntaxError:
+		return &SyntaxError{Offset: e.Offset, Err: err}
+	case *SyntaxError, *LimitExceededError:
+		return err
+	}
+
+	if err == io.EOF || err == io.ErrUnexpectedEOF {
+		err = errUnexpectedEnd
+	}
+	return &SyntaxError{Offset: r.decoder.InputOffset(), Err: err}
+}
+
+// Returns position of object key, -1 if absent
+func (n *node) index(key string) int {
+	for i, k := range n.keys {
+		if k == key {
+			return i
+		}
+	}
+	return -1
+}
+
+// Returns object member, nil if absent
+func (n *node) member(key string) *node {
+	if i := n.index(key); i >= 0 {
+		return n.members[i]
+	}
+	return nil
+}
+
+// Returns string member of object
+func (n *node) stringMember(key string) (string, bool) {
+	if m := n.member(key); m != nil && m.kind == StringValue {
+		return m.text, true
+	}
+	return "", false
+}
+
+func (n *node) isNull() bool {
+	return n == nil || n.kind == NullValue
+}
+
+// Reports whether every item of array has one of given kinds, nulls are accepted as well
+func (n *node) itemsOf(kinds ...ValueKind) bool {
+	for _, item := range n.items {
+		if !item.isNull() && !kindIn(item.kind, kinds) {
+			return false
+		}
+	}
+	return true
+}
+
+// Reports whether every member of object has one of given kinds, nulls are accepted as well
+func (n *node) membersOf(kinds ...ValueKind) bool {
+	for _, member := range n.members {
+		if !member.isNull() && !kindIn(member.kind, kinds) {
+			return false
+		}
+	}
+	return true
+}
+
+func kindIn(kind ValueKind, kinds []ValueKind) bool {
+	for _, k := range kinds {
+		if k == kind {
+			return true
+		}
+	}
+	return false
+}
+
+// Returns json of the value
+func (n *node) raw() json.RawMessage {
+	if n.encoded == nil {
+		var buf bytes.Buffer
+		n.encode(&buf)
+		n.encoded = buf.Bytes()
+	}
+	return n.encoded
+}
+
+func (n *node) encode(buf *bytes.Buffer) {
+	switch n.kind {
+	case StringValue:
+		writeString(buf, n.text)
+	case NumberValue:
+		buf.WriteString(n.text)
+	case BoolValue:
+		if n.boolean {
+			buf.WriteString("true")
+		} else {
+			buf.WriteString("false")
+		}
+	case ObjectValue:
+		buf.WriteByte('{')
+		for i, key := range n.keys {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			writeString(buf, key)
+			buf.WriteByte(':')
+			n.members[i].encode(buf)
+		}
+		buf.WriteByte('}')
+	case ArrayValue:
+		buf.WriteByte('[')
+		for i, item := range n.items {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			item.encode(buf)
+		}
+		buf.WriteByte(']')
+	default:
+		buf.WriteString("null")
+	}
+}
+
+const hexDigits = "0123456789abcdef"
+
+// Writes json string, escaping quotes, backslashes and control characters only
+func writeString(buf *bytes.Buffer, s string) {
+	buf.WriteByte('"')
+	for i := 0; i < len(s); {
+		c := s[i]
+		switch {
+		case c == '"' || c == '\\':
+			buf.WriteByte('\\')
+			buf.WriteByte(c)
+		case c == '\n':
+			buf.WriteString(`\n`)
+		case c == '\r':
+			buf.WriteString(`\r`)
+		case c == '\t':
+			buf.WriteString(`\t`)
+		case c < 0x20:
+			buf.WriteString(`\u00`)
+			buf.WriteByte(hexDigits[c>>4])
+			buf.WriteByte(hexDigits[c&0xF])
+		case c < utf8.RuneSelf:
+			buf.WriteByte(c)
+		default:
+			r, size := utf8.DecodeRuneInString(s[i:])
+			if r == utf8.RuneError && size == 1 {
+				buf.WriteString(`\ufffd`)
+			} else {
+				buf.WriteString(s[i : i+size])
+			}
+			i += size
+			continue
+		}
+		i++
+	}
+	buf.WriteByte('"')
+}
+
+// Returns value as decoded by json.Unmarshal with UseNumber
+func (n *node) interfaceValue() interface{} {
+	switch n.kind {
+	case StringValue:
+		return n.text
+	case NumberValue:
+		return json.Number(n.text)
+	case BoolValue:
+		return n.boolean
+	case ObjectValue:
+		m := make(map[string]interface{}, len(n.keys))
+		for i, key := range n.keys {
+			m[key] = n.members[i].interfaceValue()
+		}
+		return m
+	case ArrayValue:
+		s := make([]interface{}, 0, len(n.items))
+		for _, item := range n.items {
+			s = append(s, item.interfaceValue())
+		}
+		return s
+	}
+	return nil
+}
+
+// Returns Value of node
+func (n *node) value() Value {
+	return Value{Kind: n.kind, Value: n.interfaceValue(), Raw: n.raw()}
+}
+
+// Unmarshals value to v
+func (n *node) unmarshal(v interface{}) error {
+	return json.Unmarshal(n.raw(), v)
+}
+
+// Unmarshals object member to v, returns false if member is absent, null or has another type
+func (n *node) unmarshalMember(key string, v interface{}) bool {
+	m := n.member(key)
+	if m.isNull() {
+		return false
+	}
+	return m.unmarshal(v) == nil
+}
@@ -0,0 +1,59 @@
+package go_json_errors_parser
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestDecodeNode(t *testing.T) {
+	n, err := decodeNode([]byte(`{"a": [1, "x", null], "b": {"c": true}, "a": {"d": 2.50}}`))
+	assert.NoError(t, err)
+
+	assert.Equal(t, ObjectValue, n.kind)
+	assert.Equal(t, []string{"a", "b"}, n.keys)
+
+	// Duplicated key takes the last value at the first position, source json is kept as is
+	assert.Equal(t, `{"d": 2.50}`, string(n.member("a").raw()))
+	assert.Equal(t, "2.50", n.member("a").member("d").text)
+	assert.Equal(t, `{"c": true}`, string(n.member("b").raw()))
+}
+
+func TestDecodeNodeEncode(t *testing.T) {
+	n, err := decodeNode([]byte(`{"a": ["x\ty", "é", null], "b": {"c": false}}`))
+	assert.NoError(t, err)
+
+	n.encoded = nil
+	n.member("a").encoded = nil
+	n.member("b").encoded = nil
+	assert.Equal(t, `{"a":["x\ty","é",null],"b":{"c":false}}`, string(n.raw()))
+}
+
+func TestDecodeNodeSyntaxError(t *testing.T) {
+	for _, data := range []string{`{"a": 1,}`, `{"a": [1, 2}`, `{"a": 1`, `{"a": 1} {}`} {
+		_, err := decodeNode([]byte(data))
+
+		_, ok := err.(*SyntaxError)
+		assert.True(t, ok, data)
+	}
+}
+
+func TestShapeOf(t *testing.T) {
+	shapes := map[string]string{
+		`true`:                  "boolValue",
+		`null`:                  "boolValue",
+		`1.5`:                   "numValue",
+		`"msg"`:                 "stringError",
+		`["a", null]`:           "sliceStringError",
+		`[{"a": 1}, null]`:      "sliceMapStringInterfaceError",
+		`{"a": ["x"], "b": []}`: "mapStringSliceInterfaceError",
+		`{"a": "x", "b": 1}`:    "mapStringInterfaceError",
+		`{"a": {"b": "x"}}`:     "",
+		`[1, "mixed"]`:          "",
+	}
+
+	for data, shape := range shapes {
+		n, err := decodeNode([]byte(data))
+		assert.NoError(t, err)
+		assert.Equal(t, shape, shapeOf(n), data)
+	}
+}
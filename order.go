@@ -0,0 +1,91 @@
+package go_json_errors_parser
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Key of ParsedErrors ordering
+type SortKey int
+
+const (
+	// Order values are found in document, which is the default
+	SortDocument SortKey = iota
+	// JSON Pointer order, array indexes compared as numbers
+	SortPath
+	// Order of rendered messages
+	SortMessage
+	// Server errors first, then auth, rate limit, not found and conflict, validation and other errors
+	SortSeverity
+)
+
+// Sorts parsed errors by given keys, next key breaks ties of the previous one.
+// Errors equal by every key stay in document order
+func SortBy(keys ...SortKey) Option {
+	return func(p *Parser) {
+		p.sortKeys = keys
+	}
+}
+
+// Stable sorts errors given in document order
+func sortParsedErrors(errs []ParsedError, keys []SortKey) {
+	if len(keys) == 0 {
+		return
+	}
+
+	indexes := make([]int, len(errs))
+	for i := range indexes {
+		indexes[i] = i
+	}
+
+	sort.SliceStable(indexes, func(i, j int) bool {
+		a, b := &errs[indexes[i]], &errs[indexes[j]]
+		for _, key := range keys {
+			if c := compareBy(key, a, b, indexes[i], indexes[j]); c != 0 {
+				return c < 0
+			}
+		}
+		return false
+	})
+
+	sorted := make([]ParsedError, len(errs))
+	for i, index := range indexes {
+		sorted[i] = errs[index]
+	}
+	copy(errs, sorted)
+}
+
+func compareBy(key SortKey, a, b *ParsedError, i, j int) int {
+	switch key {
+	case SortDocument:
+		return i - j
+	case SortPath:
+		return comparePointers(a.Pointer, b.Pointer)
+	case SortMessage:
+		return strings.Compare(a.Error(), b.Error())
+	case SortSeverity:
+		return categorySeverity(b.Category) - categorySeverity(a.Category)
+	}
+	return 0
+}
+
+// Compares JSON Pointers segment by segment, numeric segments as numbers
+func comparePointers(a, b string) int {
+	as, bs := strings.Split(a, "/"), strings.Split(b, "/")
+
+	for i := 0; i < len(as) && i < len(bs); i++ {
+		if as[i] == bs[i] {
+			continue
+		}
+
+		an, aErr := strconv.Atoi(as[i])
+		bn, bErr := strconv.Atoi(bs[i])
+		if aErr == nil && bErr == nil {
+			return an - bn
+		}
+		return strings.Compare(as[i], bs[i])
+	}
+
+	return len(as) - len(bs)
+}
@@ -0,0 +1,56 @@
+package go_json_errors_parser
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func pointers(errs *ParsedErrors) []string {
+	var ps []string
+	for _, e := range errs.ParsedErrors {
+		ps = append(ps, e.Pointer)
+	}
+	return ps
+}
+
+func TestParseDocumentOrder(t *testing.T) {
+	doc := []byte(`{"zErrors": ["z"], "data": {"errors": {"b": "1"}}, "aErrors": ["a"], "errors": [{"x": {"y": ["2"]}, "w": {"v": ["1"]}}]}`)
+
+	for i := 0; i < 20; i++ {
+		errs, err := Parse(doc)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"/zErrors", "/data/errors", "/aErrors", "/errors/0/x", "/errors/0/w"}, pointers(errs))
+	}
+}
+
+func TestParseSortBy(t *testing.T) {
+	doc := []byte(`{"errors": [
+		{"detail": "b", "status": 404},
+		{"detail": "a", "status": 500},
+		{"detail": "c", "status": 422},
+		{"detail": "a", "status": 401}
+	], "error": "unauthorized"}`)
+
+	errs, err := NewParser(SortBy(SortMessage)).Parse(doc)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"/errors/1", "/errors/3", "/errors/0", "/errors/2", "/error"}, pointers(errs))
+
+	errs, err = NewParser(SortBy(SortSeverity)).Parse(doc)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"/errors/1", "/errors/3", "/error", "/errors/0", "/errors/2"}, pointers(errs))
+
+	errs, err = NewParser(SortBy(SortPath)).Parse(doc)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"/error", "/errors/0", "/errors/1", "/errors/2", "/errors/3"}, pointers(errs))
+
+	errs, err = NewParser(SortBy(SortMessage, SortDocument)).Parse(doc)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"/errors/1", "/errors/3", "/errors/0", "/errors/2", "/error"}, pointers(errs))
+}
+
+func TestComparePointers(t *testing.T) {
+	assert.True(t, comparePointers("/errors/2", "/errors/10") < 0)
+	assert.True(t, comparePointers("/errors", "/errors/0") < 0)
+	assert.True(t, comparePointers("/b", "/a/0") > 0)
+	assert.Equal(t, 0, comparePointers("/a/1", "/a/1"))
+}
@@ -1,21 +1,58 @@
 package go_json_errors_parser
 
 import (
-	"encoding/json"
-	"fmt"
-	"github.com/pkg/errors"
-	"regexp"
+	"bytes"
+	"context"
 	"sort"
+	"strconv"
+	"strings"
 )
 
 type ParsedError struct {
 	Parent   string
 	Children map[string][]string
 	Messages []string
+
+	// RFC 6901 JSON Pointer of the value errors were extracted from
+	Pointer string
+	// JSON Pointers of every message and child, in the same order as Messages and Children
+	MessagePointers []string
+	ChildPointers   map[string][]string
+
+	// Typed members of well-known error formats, empty if the format doesn't have them
+	Code   string `json:",omitempty"`
+	Status int    `json:",omitempty"`
+	Title  string `json:",omitempty"`
+	// Path of the request field the error refers to, e.g. JSON:API source pointer
+	Field string `json:",omitempty"`
+
+	// Problem Details members, if errors were found in RFC 7807 object
+	Problem *Problem `json:",omitempty"`
+	// JSON:API error object members, if errors were found in JSON:API errors array
+	JSONAPI *JSONAPIError `json:",omitempty"`
+	// GraphQL error members, if errors were found in GraphQL response
+	GraphQL *GraphQLError `json:",omitempty"`
+	// Canonical code and details, if errors were found in Google API error
+	Google *GoogleStatus `json:",omitempty"`
+	// Request id and error code, if errors were found in JSON-RPC response
+	RPC *JSONRPCError `json:",omitempty"`
+
+	// Original json values of children, in the same order as Children
+	Values map[string][]Value `json:",omitempty"`
+
+	// Category set by parser category rules
+	Category Category `json:",omitempty"`
+	// Name of extractor or recognized format the error was found by
+	Extractor string `json:",omitempty"`
 }
 
 type ParsedErrors struct {
 	ParsedErrors []ParsedError
+
+	// Set when response carries data along with errors, e.g. GraphQL partial success
+	Partial bool `json:",omitempty"`
+	// HTTP response the errors were parsed from, set by ParseResponse
+	Response *ResponseInfo `json:",omitempty"`
 }
 
 func (pe *ParsedErrors) IsErrors() bool {
@@ -26,164 +63,484 @@ func (pe *ParsedErrors) GetCount() int {
 	return len(pe.ParsedErrors)
 }
 
-func (pe *ParsedErrors) GetErrors() []error {
+// Option of GetErrors rendering
+type RenderOption func(*renderOptions)
+
+type renderOptions struct {
+	pointers bool
+}
+
+// Renders every error prefixed by its JSON Pointer instead of parent and child names,
+// for example "[/data/materials/1/errors/destination/0] msg"
+func WithPointers() RenderOption {
+	return func(o *renderOptions) {
+		o.pointers = true
+	}
+}
+
+func (pe *ParsedErrors) GetErrors(opts ...RenderOption) []error {
+
+	var options renderOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
 
 	var errs []error
 
 	for _, parsedError := range pe.ParsedErrors {
+		errs = append(errs, parsedError.render(options)...)
+	}
 
-		// Collect errors from Messages
-		if len(parsedError.Messages) > 0 {
-			for _, msg := range parsedError.Messages {
-				errs = append(errs, errors.New("[] "+msg))
-			}
+	sortErrors(errs)
+
+	return errs
+}
+
+// Renders every message and child as separate error unwrapping to parsed error
+func (e ParsedError) render(options renderOptions) []error {
+
+	var errs []error
+
+	// Collect errors from Messages
+	for i, msg := range e.Messages {
+		prefix := "[]"
+		if options.pointers {
+			prefix = "[" + e.messagePointer(i) + "]"
 		}
+		errs = append(errs, &renderedError{msg: prefix + " " + msg, parsed: e})
+	}
 
-		// Collect errors from children
-		for name, children := range parsedError.Children {
-			for _, child := range children {
-				errStr := "[" + parsedError.Parent + "][" + name + "] " + child
-				errs = append(errs, errors.New(errStr))
+	// Collect errors from children
+	for name, children := range e.Children {
+		for i, child := range children {
+			prefix := "[" + e.Parent + "][" + name + "]"
+			if options.pointers {
+				prefix = "[" + e.childPointer(name, i) + "]"
 			}
+			errs = append(errs, &renderedError{msg: prefix + " " + child, parsed: e})
 		}
 	}
 
+	return errs
+}
+
+func sortErrors(errs []error) {
 	sort.Slice(errs[:], func(i, j int) bool {
 		return errs[i].Error() < errs[j].Error()
 	})
+}
+
+// Error message rendered by GetErrors, keeps parsed error it came from
+type renderedError struct {
+	msg    string
+	parsed ParsedError
+}
+
+func (e *renderedError) Error() string {
+	return e.msg
+}
 
+func (e *renderedError) Unwrap() error {
+	return e.parsed
+}
+
+// Returns all messages and children of error, sorted and joined by "; "
+func (e ParsedError) Error() string {
+	errs := e.render(renderOptions{})
+	sortErrors(errs)
+
+	var msgs []string
+	for _, err := range errs {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// Returns all parsed errors, sorted and joined by "; "
+func (pe *ParsedErrors) Error() string {
+	if !pe.IsErrors() {
+		return "jerrparser: no errors"
+	}
+
+	var msgs []string
+	for _, err := range pe.GetErrors() {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// Returns every parsed error, so errors.Is and errors.As look into them
+func (pe *ParsedErrors) Unwrap() []error {
+	var errs []error
+	for _, parsedError := range pe.ParsedErrors {
+		errs = append(errs, parsedError)
+	}
 	return errs
 }
 
-// Main method
+// Returns parsed errors as error, or nil if there are no errors
+func (pe *ParsedErrors) Err() error {
+	if !pe.IsErrors() {
+		return nil
+	}
+	return pe
+}
+
+// Falls back to error pointer when message pointers are not filled
+func (e ParsedError) messagePointer(i int) string {
+	if i < len(e.MessagePointers) {
+		return e.MessagePointers[i]
+	}
+	return e.Pointer
+}
+
+func (e ParsedError) childPointer(name string, i int) string {
+	if pointers := e.ChildPointers[name]; i < len(pointers) {
+		return pointers[i]
+	}
+	return e.Pointer + "/" + pointerEscaper.Replace(name)
+}
+
+// Parser holds extractors and settings used while walking json.
+// Parser is safe for concurrent use
+type Parser struct {
+	registry    *Registry
+	keys        *KeyMatcher
+	recognizers []recognizer
+	maxBodySize int64
+	limits      Limits
+	nulls       bool
+
+	legacySliceMaps bool
+	sortKeys        []SortKey
+	categoryRules   []CategoryRule
+	logger          Logger
+
+	// Set on parser copy made by ParseContext for single parse
+	ctx    context.Context
+	walked int
+}
+
+// Recognizes well-known error object formats before object keys are walked.
+// Returns keys of the object consumed by recognized format
+type recognizer func(p *Parser, item *node, ps *ParsedErrors, parent string, path []string) ([]string, error)
+
+// Option configures Parser
+type Option func(*Parser)
+
+// Sets registry of extractors used by parser
+func WithRegistry(r *Registry) Option {
+	return func(p *Parser) {
+		p.registry = r
+	}
+}
+
+// Extracts arrays of objects found under error keys as single error holding
+// children of the last element only, like versions before every element became separate error
+func WithLegacySliceMaps() Option {
+	return func(p *Parser) {
+		p.legacySliceMaps = true
+	}
+}
+
+// Returns parser with built-in extractors, changed by given options
+func NewParser(opts ...Option) *Parser {
+	p := &Parser{
+		registry:      DefaultRegistry(),
+		keys:          DefaultKeyMatcher(),
+		maxBodySize:   DefaultMaxBodySize,
+		categoryRules: DefaultCategoryRules(),
+		recognizers: []recognizer{
+			(*Parser).recognizeProblem,
+			(*Parser).recognizeGraphQL,
+		},
+	}
+
+	for _, opt := range opts {
+		opt(p)
+	}
+
+	return p
+}
+
+var defaultParser = NewParser()
+
+// Extractors used to detect shape of values while walking,
+// independently of the extractors registered in parser
+var shapes = primitiveRegistry()
+
+// Main method, kept for backward compatibility.
+// Returns empty result if the given json can't be parsed, use Parse to get the error
 func ParseErrors(jsn string) *ParsedErrors {
+	errs, _ := Parse([]byte(jsn))
+	return errs
+}
+
+// Parses given json with default parser
+func Parse(data []byte) (*ParsedErrors, error) {
+	return defaultParser.Parse(data)
+}
+
+// Parses given json and returns found errors in document order, or in order set by SortBy.
+// On failure returns *SyntaxError, *StructureError or *LimitExceededError along with errors found so far
+func (p *Parser) Parse(data []byte) (*ParsedErrors, error) {
+	return p.parse(newNodeReader(bytes.NewReader(data), data, p.limits))
+}
+
+func (p *Parser) parse(r *nodeReader) (*ParsedErrors, error) {
 
 	errs := ParsedErrors{}
 
-	// Unmarshal given json to temporary map
-	var tmpMap map[string]*json.RawMessage
+	// Value read before exceeded limit or done context is walked as well
+	root, err := r.decode()
+	if root == nil {
+		return &errs, err
+	}
+	if _, ok := err.(*contextError); ok {
+		// Context is set on parser copy only, partial value is walked without checking it again
+		p.ctx = nil
+	}
 
-	if err := json.Unmarshal([]byte(jsn), &tmpMap); err != nil {
-		panic(err)
+	if walkErr := p.walkRoot(root, &errs); err == nil {
+		err = walkErr
+	}
+	if limitErr := p.limitErrors(&errs); err == nil {
+		err = limitErr
 	}
 
-	walk(tmpMap, &errs, "")
-	debugMessage("Final result struct:")
-	debugStruct(errs)
+	p.categorize(errs.ParsedErrors)
+	sortParsedErrors(errs.ParsedErrors, p.sortKeys)
 
-	sort.Slice(errs.ParsedErrors[:], func(i, j int) bool {
-		return len(errs.ParsedErrors[i].Messages) < len(errs.ParsedErrors[j].Messages)
-	})
+	if p.logger != nil {
+		p.logger.Debug("jerrparser: parsed", "errors", len(errs.ParsedErrors), "error", err)
+	}
 
-	return &errs
+	return &errs, err
 }
 
-// Recursively walks throw entire json, unmarshal and
-// search substring 'error' by regexp (case insensitive match) in keys and values
-// and puts found errors into struct
-func walk(item map[string]*json.RawMessage, ps *ParsedErrors, parent string) {
+// Walks document root, which may be any json value
+func (p *Parser) walkRoot(root *node, ps *ParsedErrors) error {
+	return p.walkValue(root, ps, "", nil)
+}
 
-	debugMessage("intermediate result")
-	debugStruct(ps)
+// Walks value taken as a whole for errors, like document root.
+// Objects are walked as usual, arrays are walked object by object,
+// strings or string slices are taken as error messages themselves, and other values are skipped
+func (p *Parser) walkValue(value *node, ps *ParsedErrors, parent string, path []string) error {
 
-	re := regexp.MustCompile(`(?i)(.+|.?)(error)(.+|.?)`)
+	if value.kind == ObjectValue {
+		return p.walk(value, ps, parent, path)
+	}
 
-	for key, s := range item {
+	name := shapeOf(value)
 
-		debugMessagef("Key: %s\n", key)
-		debugMessagef("Value: %s\n", s)
+	if p.logger != nil {
+		p.logger.Debug("jerrparser: value", "path", jsonPointer(path), "shape", name)
+	}
 
-		// check if errors in value
-		str := fmt.Sprintf("%s", s)
-		if re.MatchString(str) {
-			debugMessagef("ERROR FOUND IN VALUE: %s\n", str)
-
-			var unmarshaledError stringError
-			unmarshaledError.RawMessage = *s
-			err := unmarshaledError.unmarshalJson()
-			if err == nil {
-				unmarshaledError.transferTo(ps, parent)
-				continue
-			} else {
-				debugMessage("Error-in-value not works: can't unmarshal")
-				debugMessage(err.Error())
-			}
+	switch name {
+	case "stringError", "sliceStringError":
+		if err := p.extract(value, ps, parent, path); err != nil {
+			return err
+		}
+	case "sliceMapStringInterfaceError", "":
+		if err := p.walkItems(value, ps, parent, path); err != nil {
+			return err
 		}
+	}
 
-		if re.MatchString(string(key)) {
-			debugMessagef("ERROR FOUND IN KEY: %s\n", key)
+	return nil
+}
 
-			if s == nil {
-				continue
+// Walks objects of array, other items are skipped
+func (p *Parser) walkItems(value *node, ps *ParsedErrors, parent string, path []string) error {
+	for i, item := range value.items {
+		if item.kind != ObjectValue {
+			continue
+		}
+		if err := p.walk(item, ps, parent, appendPath(path, strconv.Itoa(i))); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Extracts errors of value found under error key with the first matching extractor.
+// Null children are dropped unless parser keeps them, nested objects and arrays
+// are extracted on their own, or walked if no extractor matches them
+func (p *Parser) extract(value *node, ps *ParsedErrors, parent string, path []string) error {
+
+	extractor, name, ok := p.registry.match(value)
+	if !ok {
+		if p.logger != nil {
+			p.logger.Debug("jerrparser: extract", "path", jsonPointer(path), "outcome", "no match")
+		}
+
+		if value.kind == ObjectValue {
+			return p.walk(value, ps, parent, path)
+		}
+		return nil
+	}
+
+	if e, ok := extractor.(*sliceMapStringInterfaceError); ok {
+		e.legacy = p.legacySliceMaps
+	}
+
+	n := len(ps.ParsedErrors)
+	if err := extractor.TransferTo(ps, parent, path); err != nil {
+		return wrapUnmarshalError(err, path)
+	}
+
+	for i := range ps.ParsedErrors[n:] {
+		if ps.ParsedErrors[n+i].Extractor == "" {
+			ps.ParsedErrors[n+i].Extractor = name
+		}
+	}
+
+	if !p.nulls {
+		extracted := ps.ParsedErrors[:n]
+		for _, e := range ps.ParsedErrors[n:] {
+			if !e.dropNulls() {
+				extracted = append(extracted, e)
 			}
+		}
+		ps.ParsedErrors = extracted
+	}
 
-			err := batchExtract(*s, ps, parent)
-			if err == nil {
-				continue
-			} else {
-				debugMessage(err.Error())
+	if p.logger != nil {
+		p.logger.Debug("jerrparser: extract", "path", jsonPointer(path), "extractor", name,
+			"outcome", "extracted", "errors", len(ps.ParsedErrors)-n)
+	}
+
+	if nested, ok := extractor.(nestedExtractor); ok {
+		for _, v := range nested.nestedValues() {
+			if err := p.extract(v.node, ps, v.parent, v.path); err != nil {
+				return err
 			}
+		}
+	}
 
-		} else {
+	return nil
+}
 
-			debugMessage("ELSE fires:")
-			debugStruct(s)
+// Reports whether string value holds error message, that is contains "error" in any case
+func isErrorValue(n *node) bool {
+	const word = "error"
 
-			if s == nil {
-				debugMessage("detect s is nil, skipping..")
-				continue
-			}
+	s := n.text
+	for i := 0; i+len(word) <= len(s); i++ {
+		j := 0
+		for j < len(word) && s[i+j]|0x20 == word[j] {
+			j++
+		}
+		if j == len(word) {
+			return true
+		}
+	}
+	return false
+}
 
-			debugMessage("Checking all with continue on mapStringSliceInterfaceError")
-			err, cont := batchCheck(*s, []string{"mapStringSliceInterfaceError"})
-			if (err == nil) && cont {
+// Recursively walks throw entire json, search keys matched by parser key matcher
+// and values containing substring 'error' and puts found errors into struct.
+// Shape of every value is checked once on the decoded document, without unmarshaling
+func (p *Parser) walk(item *node, ps *ParsedErrors, parent string, path []string) error {
 
-				var tmpMap map[string]*json.RawMessage
-				err = json.Unmarshal(*s, &tmpMap)
-				checkErr(err)
+	if err := p.checkContext(); err != nil {
+		return err
+	}
 
-				debugMessage("detect mapStringSliceInterfaceError, going deeper..")
-				walk(tmpMap, ps, key)
+	var consumed []string
+	for _, recognize := range p.recognizers {
+		keys, err := recognize(p, item, ps, parent, path)
+		if err != nil {
+			return err
+		}
+		consumed = append(consumed, keys...)
+	}
 
-				continue
-			} else {
-				if err != nil {
-					debugMessage(err.Error())
-				}
-			}
+	// Ranges of errors found under keys of this object
+	var found [][2]int
+	var err error
 
-			debugMessage("Checking all with continue on sliceMapStringInterfaceError")
-			err, cont = batchCheck(*s, []string{"sliceMapStringInterfaceError"})
-			if err == nil {
-				if cont {
-					var tmpMap []map[string]*json.RawMessage
-					err = json.Unmarshal(*s, &tmpMap)
-					checkErr(err)
+	for i, key := range item.keys {
+		s := item.members[i]
 
-					debugMessage("detect sliceMapStringInterfaceError, going deeper..")
+		if err = p.limitErrors(ps); err != nil {
+			break
+		}
+
+		if stringInSlice(key, consumed) {
+			continue
+		}
 
-					for _, value := range tmpMap {
+		keyPath := appendPath(path, key)
+
+		// check if errors in value
+		if s.kind == StringValue && isErrorValue(s) {
+			p.traceKey(keyPath, "error value")
 
-						debugMessage("parsing sub struct")
-						debugMessagef("%s", value)
+			n := len(ps.ParsedErrors)
+			e := stringError{nodeSource: nodeSource{node: s}}
+			if err := e.TransferTo(ps, parent, keyPath); err != nil {
+				return wrapUnmarshalError(err, keyPath)
+			}
+			ps.ParsedErrors[n].Extractor = "errorValue"
+			found = append(found, [2]int{n, len(ps.ParsedErrors)})
+			continue
+		}
+
+		if s.isNull() {
+			p.traceKey(keyPath, "null")
+			continue
+		}
 
-						walk(value, ps, key)
-					}
-				}
+		if p.keys.Match(key) {
+			p.traceKey(keyPath, "error key")
 
-				debugMessage("Err is nil but cont is false")
+			n := len(ps.ParsedErrors)
+			if err := p.extract(s, ps, parent, keyPath); err != nil {
+				return err
+			}
+			found = append(found, [2]int{n, len(ps.ParsedErrors)})
+			continue
+		}
 
+		switch shapeOf(s) {
+		case "mapStringSliceInterfaceError":
+			p.traceKey(keyPath, "descend")
+			if err := p.walk(s, ps, key, keyPath); err != nil {
+				return err
+			}
+		case "sliceMapStringInterfaceError":
+			p.traceKey(keyPath, "descend")
+			if err := p.walkItems(s, ps, key, keyPath); err != nil {
+				return err
+			}
+		case "":
+			// Arrays of numbers, mixed or nested values hold no errors
+			if s.kind != ObjectValue {
+				p.traceKey(keyPath, "skip")
 				continue
-			} else {
-				debugMessage(err.Error())
 			}
 
-			var tmpMap map[string]*json.RawMessage
-			err = json.Unmarshal(*s, &tmpMap)
-			checkErr(err)
+			p.traceKey(keyPath, "descend")
+			if err := p.walk(s, ps, key, keyPath); err != nil {
+				return err
+			}
+		default:
+			p.traceKey(keyPath, "skip")
+		}
+	}
 
-			debugMessage("PARENT set to: " + key)
-			walk(tmpMap, ps, key)
+	for _, r := range found {
+		// Errors beyond the limit are dropped already
+		if r[1] > len(ps.ParsedErrors) {
+			r[1] = len(ps.ParsedErrors)
+		}
+		if r[0] < r[1] {
+			stampSiblingCodes(item, ps.ParsedErrors[r[0]:r[1]])
 		}
 	}
+
+	return err
 }
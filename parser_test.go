@@ -4,6 +4,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"io/ioutil"
 	"testing"
+	"time"
 )
 
 func TestParseErrorsExample1(t *testing.T) {
@@ -15,9 +16,9 @@ func TestParseErrorsExample1(t *testing.T) {
 
 	assert.Equal(t, 2, errs.GetCount())
 	assert.Equal(t, true, errs.IsErrors())
-	assert.Equal(t, "data", errs.ParsedErrors[0].Parent)
-	assert.Equal(t, "Validations failed for package 'c4b10faf-62f9-4b75-ae7f-9dc042e3d310'. Error(s): [Validation failed.]. Please correct and resubmit.", errs.ParsedErrors[1].Messages[0])
-	assert.Equal(t, "Package spec not specified", errs.ParsedErrors[0].Children["PACKAGE_SPEC"][0])
+	assert.Equal(t, "data", errs.ParsedErrors[1].Parent)
+	assert.Equal(t, "Validations failed for package 'c4b10faf-62f9-4b75-ae7f-9dc042e3d310'. Error(s): [Validation failed.]. Please correct and resubmit.", errs.ParsedErrors[0].Messages[0])
+	assert.Equal(t, "Package spec not specified", errs.ParsedErrors[1].Children["PACKAGE_SPEC"][0])
 }
 
 func TestParseErrorsExample2(t *testing.T) {
@@ -38,9 +39,9 @@ func TestParseErrorsExample3(t *testing.T) {
 
 	errs := ParseErrors(string(file))
 
-	assert.Equal(t, "data", errs.ParsedErrors[0].Parent)
-	assert.Equal(t, "A pipeline must have at least one material", errs.ParsedErrors[0].Children["materials"][0])
-	assert.Equal(t, "Invalid label '123'. Label should be composed of alphanumeric text, it can contain the build number as ${COUNT}, can contain a material revision as ${<material-name>} of ${<material-name>[:<number>]}, or use params as #{<param-name>}.", errs.ParsedErrors[0].Children["label_template"][0])
+	assert.Equal(t, "data", errs.ParsedErrors[1].Parent)
+	assert.Equal(t, "A pipeline must have at least one material", errs.ParsedErrors[1].Children["materials"][0])
+	assert.Equal(t, "Invalid label '123'. Label should be composed of alphanumeric text, it can contain the build number as ${COUNT}, can contain a material revision as ${<material-name>} of ${<material-name>[:<number>]}, or use params as #{<param-name>}.", errs.ParsedErrors[1].Children["label_template"][0])
 }
 
 func TestParseErrorsExample4(t *testing.T) {
@@ -50,8 +51,8 @@ func TestParseErrorsExample4(t *testing.T) {
 
 	errs := ParseErrors(string(file))
 
-	assert.Equal(t, "data", errs.ParsedErrors[0].Parent)
-	assert.Equal(t, "A pipeline must have at least one material", errs.ParsedErrors[0].Children["materials"][0])
+	assert.Equal(t, "data", errs.ParsedErrors[1].Parent)
+	assert.Equal(t, "A pipeline must have at least one material", errs.ParsedErrors[1].Children["materials"][0])
 }
 
 func TestParseErrorsExample5(t *testing.T) {
@@ -61,9 +62,9 @@ func TestParseErrorsExample5(t *testing.T) {
 
 	errs := ParseErrors(string(file))
 
-	assert.Equal(t, "materials", errs.ParsedErrors[0].Parent)
-	assert.Equal(t, 0, len(errs.ParsedErrors[0].Messages))
-	assert.Equal(t, "Invalid Destination Directory. Every material needs a different destination directory and the directories should not be nested.", errs.ParsedErrors[1].Children["destination"][0])
+	assert.Equal(t, "materials", errs.ParsedErrors[1].Parent)
+	assert.Equal(t, 0, len(errs.ParsedErrors[1].Messages))
+	assert.Equal(t, "Invalid Destination Directory. Every material needs a different destination directory and the directories should not be nested.", errs.ParsedErrors[2].Children["destination"][0])
 }
 
 func TestParseErrorsExample6(t *testing.T) {
@@ -149,5 +150,283 @@ func TestParsedErrors_GetErrors(t *testing.T) {
 	errs := ParseErrors(string(file))
 
 	errors := errs.GetErrors()
-	assert.Equal(t, "[data][materials] A pipeline must have at least one material", errors[3].Error())
+	assert.Equal(t, "[data][materials] A pipeline must have at least one material", errors[2].Error())
+}
+
+func TestParseSyntaxError(t *testing.T) {
+	errs, err := Parse([]byte(`{"error": "Unauthorized",}`))
+
+	assert.NotNil(t, errs)
+	assert.Equal(t, false, errs.IsErrors())
+
+	syntaxErr, ok := err.(*SyntaxError)
+	assert.True(t, ok)
+	assert.Equal(t, int64(26), syntaxErr.Offset)
+	assert.Equal(t, "", syntaxErr.Path)
+}
+
+func TestParseUnknownShapes(t *testing.T) {
+	errs, err := Parse([]byte(`{"data": {"list": [1, "mixed", [2]], "error": "Invalid list"}}`))
+	assert.NoError(t, err)
+	assert.Equal(t, 1, errs.GetCount())
+	assert.Equal(t, "Invalid list", errs.ParsedErrors[0].Messages[0])
+
+	errs = ParseErrors(`{"ids": [1, 2, 3], "error": "x"}`)
+	assert.Equal(t, 1, errs.GetCount())
+	assert.Equal(t, "x", errs.ParsedErrors[0].Messages[0])
+}
+
+func TestParseErrorsMalformed(t *testing.T) {
+	assert.NotPanics(t, func() {
+		errs := ParseErrors(`{"error": `)
+		assert.Equal(t, false, errs.IsErrors())
+	})
+}
+
+func TestParseRootArray(t *testing.T) {
+	errs, err := Parse([]byte(`[{"error": "x"}, {"id": 2, "errors": ["y", "z"]}]`))
+	assert.NoError(t, err)
+	assert.Equal(t, 2, errs.GetCount())
+	assert.Equal(t, "x", errs.ParsedErrors[0].Messages[0])
+	assert.Equal(t, []string{"y", "z"}, errs.ParsedErrors[1].Messages)
+
+	errs, err = Parse([]byte(`["Unauthorized", "Auth required"]`))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Unauthorized", "Auth required"}, errs.ParsedErrors[0].Messages)
+	assert.Equal(t, "", errs.ParsedErrors[0].Parent)
+	// numbers and nested arrays hold no errors
+	for _, data := range []string{`[1, 2]`, `[["a"]]`} {
+		errs, err = Parse([]byte(data))
+		assert.NoError(t, err, data)
+		assert.Equal(t, false, errs.IsErrors(), data)
+	}
+
+	// objects of mixed array are walked
+	errs, err = Parse([]byte(`[{"error": "x"}, "y", 3]`))
+	assert.NoError(t, err)
+	assert.Equal(t, 1, errs.GetCount())
+	assert.Equal(t, "x", errs.ParsedErrors[0].Messages[0])
+	assert.Equal(t, "/0/error", errs.ParsedErrors[0].Pointer)
+
+}
+
+func TestParseRootScalar(t *testing.T) {
+	errs, err := Parse([]byte(`"Internal Server Error"`))
+	assert.NoError(t, err)
+	assert.Equal(t, "Internal Server Error", errs.ParsedErrors[0].Messages[0])
+
+	errs, err = Parse([]byte(`true`))
+	assert.NoError(t, err)
+	assert.Equal(t, false, errs.IsErrors())
+
+	errs, err = Parse([]byte(`null`))
+	assert.NoError(t, err)
+	assert.Equal(t, false, errs.IsErrors())
+}
+
+func TestParseErrorsPointers(t *testing.T) {
+
+	file, e := ioutil.ReadFile("tests/example5.json")
+	assert.NoError(t, e)
+
+	errs := ParseErrors(string(file))
+
+	var pointers []string
+	for _, parsedError := range errs.ParsedErrors {
+		pointers = append(pointers, parsedError.Pointer)
+	}
+	assert.ElementsMatch(t, []string{"/message", "/data/materials/0/errors", "/data/materials/1/errors"}, pointers)
+
+	errors := errs.GetErrors(WithPointers())
+	assert.Equal(t, "[/data/materials/1/errors/destination/1] The destination directory must be unique across materials.", errors[3].Error())
+	assert.Equal(t, "[/message] Validations failed for pipeline 'FromTemplate3'. Error(s): [Validation failed.]. Please correct and resubmit.", errors[4].Error())
+}
+
+func TestParseRootArrayPointers(t *testing.T) {
+	errs, err := Parse([]byte(`[{"id": 1}, {"errors": {"name": "required", "a/b": "invalid"}}]`))
+	assert.NoError(t, err)
+	assert.Equal(t, "/1/errors", errs.ParsedErrors[0].Pointer)
+	assert.Equal(t, "/1/errors/a~1b", errs.ParsedErrors[0].ChildPointers["a/b"][0])
+}
+
+func TestParseErrorsExample13(t *testing.T) {
+
+	file, e := ioutil.ReadFile("tests/example13.json")
+	assert.NoError(t, e)
+
+	errs := ParseErrors(string(file))
+	assert.Equal(t, 1, errs.GetCount())
+
+	problem := errs.ParsedErrors[0].Problem
+	assert.NotNil(t, problem)
+	assert.Equal(t, "https://example.net/validation-error", problem.Type)
+	assert.Equal(t, 400, problem.Status)
+	assert.Equal(t, "/account/12345/msgs/abc", problem.Instance)
+	assert.Equal(t, `"af12cd"`, string(problem.Extensions["trace_id"]))
+
+	assert.Equal(t, "2 parameters are invalid, see invalid-params for details", errs.ParsedErrors[0].Messages[0])
+	assert.Equal(t, "must be a positive integer", errs.ParsedErrors[0].Children["age"][0])
+	assert.Equal(t, "/invalid-params/1/reason", errs.ParsedErrors[0].ChildPointers["color"][0])
+}
+
+func TestParseErrorsExample14(t *testing.T) {
+
+	file, e := ioutil.ReadFile("tests/example14.json")
+	assert.NoError(t, e)
+
+	errs := ParseErrors(string(file))
+	assert.Equal(t, 2, errs.GetCount())
+
+	var first, second ParsedError
+	for _, parsedError := range errs.ParsedErrors {
+		if parsedError.Pointer == "/errors/0" {
+			first = parsedError
+		} else {
+			second = parsedError
+		}
+	}
+
+	assert.Equal(t, "First name must contain at least two characters.", first.Messages[0])
+	assert.Equal(t, "/data/attributes/firstName", first.Field)
+	assert.Equal(t, "too_short", first.Code)
+	assert.Equal(t, 422, first.Status)
+	assert.Equal(t, "Invalid Attribute", first.Title)
+	assert.Equal(t, "a1", first.JSONAPI.ID)
+
+	assert.Equal(t, "/errors/1", second.Pointer)
+	assert.Equal(t, "Invalid Query Parameter", second.Messages[0])
+	assert.Equal(t, "include", second.Field)
+	assert.Equal(t, 400, second.Status)
+	assert.Equal(t, `["author", "comments"]`, string(second.JSONAPI.Meta["allowed"]))
+}
+
+func TestParseErrorsExample15(t *testing.T) {
+
+	file, e := ioutil.ReadFile("tests/example15.json")
+	assert.NoError(t, e)
+
+	errs := ParseErrors(string(file))
+	assert.Equal(t, 1, errs.GetCount())
+	assert.Equal(t, true, errs.Partial)
+
+	parsedError := errs.ParsedErrors[0]
+	assert.Equal(t, "Name for character with ID 1002 could not be fetched.", parsedError.Messages[0])
+	assert.Equal(t, "CAN_NOT_FETCH_BY_ID", parsedError.Code)
+	assert.Equal(t, GraphQLPath{"hero", "heroFriends", 1, "name"}, parsedError.GraphQL.Path)
+	assert.Equal(t, "hero.heroFriends.1.name", parsedError.GraphQL.Path.String())
+	assert.Equal(t, []GraphQLLocation{{Line: 6, Column: 7}}, parsedError.GraphQL.Locations)
+}
+
+func TestParseErrorsExample16(t *testing.T) {
+
+	file, e := ioutil.ReadFile("tests/example16.json")
+	assert.NoError(t, e)
+
+	errs := ParseErrors(string(file))
+	assert.Equal(t, 1, errs.GetCount())
+
+	parsedError := errs.ParsedErrors[0]
+	assert.Equal(t, "/error", parsedError.Pointer)
+	assert.Equal(t, "Request contains an invalid argument.", parsedError.Messages[0])
+	assert.Equal(t, "INVALID_ARGUMENT", parsedError.Code)
+	assert.Equal(t, 400, parsedError.Status)
+	assert.Equal(t, "Title must not be empty", parsedError.Children["book.title"][0])
+	assert.Equal(t, "/error/details/0/fieldViolations/1/description", parsedError.ChildPointers["book.isbn"][0])
+	assert.Equal(t, `"ISBN is malformed"`, string(parsedError.Values["book.isbn"][0].Raw))
+
+	status := parsedError.Google
+	assert.Equal(t, CodeInvalidArgument, status.Code)
+	assert.Equal(t, "API_KEY_INVALID", status.ErrorInfo.Reason)
+	assert.Equal(t, "library.googleapis.com", status.ErrorInfo.Metadata["service"])
+	assert.Equal(t, 1500*time.Millisecond, status.RetryDelay)
+	assert.Equal(t, "project:123", status.QuotaViolations[0].Subject)
+	assert.Equal(t, "de-DE", status.LocalizedMessage.Locale)
+	assert.Equal(t, 5, len(status.Details))
+}
+
+func TestParseErrorsExample17(t *testing.T) {
+
+	file, e := ioutil.ReadFile("tests/example17.json")
+	assert.NoError(t, e)
+
+	errs, err := NewParser(WithJSONRPC()).Parse(file)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, errs.GetCount())
+
+	byPointer := make(map[string]ParsedError)
+	for _, parsedError := range errs.ParsedErrors {
+		byPointer[parsedError.Pointer] = parsedError
+	}
+
+	invalidParams := byPointer["/1/error"]
+	assert.Equal(t, "Invalid params", invalidParams.Messages[0])
+	assert.Equal(t, "-32602", invalidParams.Code)
+	assert.Equal(t, RPCInvalidParams, invalidParams.RPC.Kind)
+	assert.Equal(t, "2", string(invalidParams.RPC.ID))
+
+	dataError := byPointer["/1/error/data/errors"]
+	assert.Equal(t, "must be a number", dataError.Children["subtrahend"][0])
+	assert.Equal(t, "2", string(dataError.RPC.ID))
+
+	parseError := byPointer["/2/error"]
+	assert.Equal(t, RPCParseError, parseError.RPC.Kind)
+	assert.Equal(t, "null", string(parseError.RPC.ID))
+}
+
+func TestParseErrorsExample7Elements(t *testing.T) {
+
+	file, e := ioutil.ReadFile("tests/example7.json")
+	assert.NoError(t, e)
+
+	errs, err := Parse(file)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, errs.GetCount())
+
+	var rendered []string
+	for _, err := range errs.GetErrors() {
+		rendered = append(rendered, err.Error())
+	}
+	assert.Equal(t, []string{
+		"[Object][objectField] objectValue",
+		"[data][FieldName2] some error",
+		"[data][FieldName] some error",
+	}, rendered)
+
+	for _, parsedError := range errs.ParsedErrors {
+		switch parsedError.Parent {
+		case "data":
+			assert.Equal(t, "/data/Errors/0", parsedError.Pointer)
+		case "Object":
+			assert.Equal(t, "/data/Errors/0/Object", parsedError.Pointer)
+		default:
+			t.Errorf("unexpected parent %q", parsedError.Parent)
+		}
+	}
+}
+
+func TestParseErrorsEveryElement(t *testing.T) {
+	errs, err := Parse([]byte(`{"errors": [{"field": "a"}, {"field": "b"}]}`))
+	assert.NoError(t, err)
+	assert.Equal(t, 2, errs.GetCount())
+
+	var rendered []string
+	for _, err := range errs.GetErrors(WithPointers()) {
+		rendered = append(rendered, err.Error())
+	}
+	assert.Equal(t, []string{"[/errors/0/field] a", "[/errors/1/field] b"}, rendered)
+}
+
+func TestParseErrorsLegacySliceMaps(t *testing.T) {
+	errs, err := NewParser(WithLegacySliceMaps()).Parse([]byte(`{"errors": [{"field": "a"}, {"field": "b"}]}`))
+	assert.NoError(t, err)
+	assert.Equal(t, 1, errs.GetCount())
+	assert.Equal(t, []string{"b"}, errs.ParsedErrors[0].Children["field"])
+
+	file, e := ioutil.ReadFile("tests/example7.json")
+	assert.NoError(t, e)
+
+	errs, err = NewParser(WithLegacySliceMaps()).Parse(file)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, errs.GetCount())
+	assert.Equal(t, "map[objectField:objectValue]", errs.ParsedErrors[0].Children["Object"][0])
 }
@@ -7,188 +7,200 @@ import (
 	"strconv"
 )
 
-type ParsedErrorInterface interface {
-	setRawMessage(m json.RawMessage)
-	unmarshalJson() error
-	transferTo(ps *ParsedErrors, parent string)
+// Extractor unmarshals json value of a particular shape
+// and transfers errors found in it to ParsedErrors
+type Extractor interface {
+	// Sets value to be unmarshaled
+	SetRawMessage(m json.RawMessage)
+	// Returns error if the value doesn't have extractor's shape
+	Unmarshal() error
+	// Appends errors of unmarshaled value, found under parent key at path
+	TransferTo(ps *ParsedErrors, parent string, path []string) error
+}
+
+// Deprecated: use Extractor
+type ParsedErrorInterface = Extractor
+
+// Embedded by built-in extractors, which transfer values already decoded
+// by parser without unmarshaling them again
+type nodeSource struct {
+	node *node
+}
+
+func (s *nodeSource) setNode(n *node) {
+	s.node = n
+}
+
+// Returns value set by parser, or decodes given raw message
+func (s *nodeSource) source(m json.RawMessage) (*node, error) {
+	if s.node != nil {
+		return s.node, nil
+	}
+	return decodeNode(m)
+}
+
+// Implemented by extractors transferring decoded values
+type nodeExtractor interface {
+	setNode(n *node)
 }
 
 // String error struct and unmarshal
 type stringError struct {
 	Error      string
 	RawMessage json.RawMessage
+	nodeSource
 }
 
-func (e *stringError) setRawMessage(m json.RawMessage) {
+func (e *stringError) SetRawMessage(m json.RawMessage) {
 	e.RawMessage = m
 }
 
-func (e *stringError) unmarshalJson() error {
+func (e *stringError) Unmarshal() error {
 	return json.Unmarshal(e.RawMessage, &e.Error)
 }
 
-func (e *stringError) transferTo(ps *ParsedErrors, parent string) {
+func (e *stringError) TransferTo(ps *ParsedErrors, parent string, path []string) error {
+	n, err := e.source(e.RawMessage)
+	if err != nil {
+		return err
+	}
+
 	r := ParsedError{}
-	r.Messages = append(r.Messages, trimQuotes(e.Error))
+	r.Messages = append(r.Messages, trimQuotes(n.text))
+	r.MessagePointers = append(r.MessagePointers, jsonPointer(path))
 	r.Parent = parent
+	r.Pointer = jsonPointer(path)
 	ps.ParsedErrors = append(ps.ParsedErrors, r)
+	return nil
 }
 
 // String slice error struct and unmarshal
 type sliceStringError struct {
 	Error      []string
 	RawMessage json.RawMessage
+	nodeSource
 }
 
-func (e *sliceStringError) setRawMessage(m json.RawMessage) {
+func (e *sliceStringError) SetRawMessage(m json.RawMessage) {
 	e.RawMessage = m
 }
 
-func (e *sliceStringError) unmarshalJson() error {
+func (e *sliceStringError) Unmarshal() error {
 	return json.Unmarshal(e.RawMessage, &e.Error)
 }
 
-func (e *sliceStringError) transferTo(ps *ParsedErrors, parent string) {
+func (e *sliceStringError) TransferTo(ps *ParsedErrors, parent string, path []string) error {
+	n, err := e.source(e.RawMessage)
+	if err != nil {
+		return err
+	}
+
 	r := ParsedError{}
-	r.Messages = append(r.Messages, e.Error...)
+	for i, item := range n.items {
+		r.Messages = append(r.Messages, item.text)
+		r.MessagePointers = append(r.MessagePointers, jsonPointer(appendPath(path, strconv.Itoa(i))))
+	}
 	r.Parent = parent
+	r.Pointer = jsonPointer(path)
 	ps.ParsedErrors = append(ps.ParsedErrors, r)
+	return nil
 }
 
 // Map of string slice interface error struct and unmarshal
 type mapStringSliceInterfaceError struct {
 	Error      map[string][]interface{}
 	RawMessage json.RawMessage
+	nodeSource
+	nestedCollector
 }
 
-func (e *mapStringSliceInterfaceError) setRawMessage(m json.RawMessage) {
+func (e *mapStringSliceInterfaceError) SetRawMessage(m json.RawMessage) {
 	e.RawMessage = m
 }
 
-func (e *mapStringSliceInterfaceError) unmarshalJson() error {
+func (e *mapStringSliceInterfaceError) Unmarshal() error {
 	return json.Unmarshal(e.RawMessage, &e.Error)
 }
 
-func (e *mapStringSliceInterfaceError) transferTo(ps *ParsedErrors, parent string) {
-	var tmpMap map[string][]interface{}
-
-	err := json.Unmarshal(e.RawMessage, &tmpMap)
+func (e *mapStringSliceInterfaceError) TransferTo(ps *ParsedErrors, parent string, path []string) error {
+	n, err := e.source(e.RawMessage)
 	if err != nil {
-		panic(err)
+		return err
 	}
 
 	r := ParsedError{}
 
-	formattedStrs := make(map[string][]string)
-	for name, str := range tmpMap {
-		var tmpMap []string
-		for _, item := range str {
-			tmpMap = append(tmpMap, fmt.Sprintf("%v", item))
+	for i, name := range n.keys {
+		for j, item := range n.members[i].items {
+			itemPath := append(appendPath(path, name), strconv.Itoa(j))
+
+			// Objects and arrays are extracted by parser instead of being stringified
+			if item.kind == ObjectValue || item.kind == ArrayValue {
+				e.addNested(item, name, itemPath)
+				continue
+			}
+			r.addChild(name, item.value(), jsonPointer(itemPath))
 		}
-		formattedStrs[name] = tmpMap
 	}
-	r.Children = formattedStrs
+
+	// Nothing left besides nested values
+	if len(r.Children) == 0 && len(e.nested) > 0 {
+		return nil
+	}
+
 	r.Parent = parent
+	r.Pointer = jsonPointer(path)
 	ps.ParsedErrors = append(ps.ParsedErrors, r)
-
+	return nil
 }
 
 // Map of string interface struct and unmarshal
 type mapStringInterfaceError struct {
 	Error      map[string]interface{}
 	RawMessage json.RawMessage
+	nodeSource
 }
 
-func (e *mapStringInterfaceError) setRawMessage(m json.RawMessage) {
+func (e *mapStringInterfaceError) SetRawMessage(m json.RawMessage) {
 	e.RawMessage = m
 }
 
-func (e *mapStringInterfaceError) unmarshalJson() error {
-	//TODO how check that in interface bool or float or num or string
-
-	err := json.Unmarshal(e.RawMessage, &e.Error)
-
-	debugMessage("Unmarshaliing mapStringInterfaceError")
-
-	if err == nil {
-		debugMessage("Unmarshaled to mapStringInterfaceError, checking values..")
-		debugMessage("len of error: " + strconv.Itoa(len(e.Error)))
-
-		for _, value := range e.Error {
-
-			debugMessagef("%s\n", value)
-
-			s := fmt.Sprintf("%s", value)
-
-			if len(s) >= 2 {
-				b := s[0]
-				e := s[len(s)-1]
-
-				if (string(b) == "{") && (string(e) == "}") {
-					debugMessage("OBJECT FOUND IN INTERFACE")
-					return errors.New("Json object found in interface value")
-				}
-
-				if (string(b) == "[") && (string(e) == "]") {
-					debugMessage("ARRAY FOUND IN INTERFACE")
-					return errors.New("Array object found in interface value")
-				}
-			}
-
-
-			if len(s) > 3 {
-				first3 := s[0:3]
+func (e *mapStringInterfaceError) Unmarshal() error {
+	if err := json.Unmarshal(e.RawMessage, &e.Error); err != nil {
+		return err
+	}
 
-				if first3 == "map" {
-					debugMessage("MAP FOUND IN INTERFACE")
-					return errors.New("Map found in interface value")
-				}
-			}
+	for _, value := range e.Error {
+		switch value.(type) {
+		case map[string]interface{}:
+			return errors.New("Json object found in interface value")
+		case []interface{}:
+			return errors.New("Array object found in interface value")
 		}
-	} else {
-		debugMessage("Error while unmarshalling")
 	}
 
-	return err
+	return nil
 }
 
-func (e *mapStringInterfaceError) transferTo(ps *ParsedErrors, parent string) {
-	r := ParsedError{}
-	r.Parent = parent
-	tmp := make(map[string][]string)
-
-	for name, err := range e.Error {
-		tmp[name] = []string{fmt.Sprintf("%v", err)}
+func (e *mapStringInterfaceError) TransferTo(ps *ParsedErrors, parent string, path []string) error {
+	n, err := e.source(e.RawMessage)
+	if err != nil {
+		return err
 	}
-
-	r.Children = tmp
-	ps.ParsedErrors = append(ps.ParsedErrors, r)
-}
-
-// Map of string string struct and unmarshal
-type mapStringStringError struct {
-	Error      map[string]string
-	RawMessage json.RawMessage
-}
-
-func (e *mapStringStringError) setRawMessage(m json.RawMessage) {
-	e.RawMessage = m
-}
-
-func (e *mapStringStringError) unmarshalJson() error {
-	return json.Unmarshal(e.RawMessage, &e.Error)
+	transferMap(n, ps, parent, path)
+	return nil
 }
 
-func (e *mapStringStringError) transferTo(ps *ParsedErrors, parent string) {
+// Transfers object of scalar values as single error with a child per member
+func transferMap(n *node, ps *ParsedErrors, parent string, path []string) {
 	r := ParsedError{}
 	r.Parent = parent
-	tmp := make(map[string][]string)
+	r.Pointer = jsonPointer(path)
 
-	for name, err := range e.Error {
-		tmp[name] = []string{fmt.Sprintf("%v", err)}
+	for i, name := range n.keys {
+		r.addChild(name, n.members[i].value(), jsonPointer(appendPath(path, name)))
 	}
 
-	r.Children = tmp
 	ps.ParsedErrors = append(ps.ParsedErrors, r)
 }
 
@@ -196,32 +208,76 @@ func (e *mapStringStringError) transferTo(ps *ParsedErrors, parent string) {
 type sliceMapStringInterfaceError struct {
 	Error      []map[string]interface{}
 	RawMessage json.RawMessage
+	nodeSource
+	nestedCollector
+
+	// Transfers whole slice as single error with children of the last element
+	legacy bool
 }
 
-func (e *sliceMapStringInterfaceError) setRawMessage(m json.RawMessage) {
+func (e *sliceMapStringInterfaceError) SetRawMessage(m json.RawMessage) {
 	e.RawMessage = m
 }
 
-func (e *sliceMapStringInterfaceError) unmarshalJson() error {
+func (e *sliceMapStringInterfaceError) Unmarshal() error {
 	return json.Unmarshal(e.RawMessage, &e.Error)
 }
 
-func (e *sliceMapStringInterfaceError) transferTo(ps *ParsedErrors, parent string) {
-
-	var strs []map[string]interface{}
-	err := json.Unmarshal(e.RawMessage, &strs)
+// Transfers every element as separate error pointing at the element,
+// objects and arrays in elements are extracted by parser instead of being stringified
+func (e *sliceMapStringInterfaceError) TransferTo(ps *ParsedErrors, parent string, path []string) error {
+	n, err := e.source(e.RawMessage)
 	if err != nil {
-		panic(err)
+		return err
 	}
+
+	if e.legacy {
+		e.transferLegacy(n, ps, parent, path)
+		return nil
+	}
+
+	for i, item := range n.items {
+		itemPath := appendPath(path, strconv.Itoa(i))
+
+		r := ParsedError{}
+		r.Parent = parent
+		r.Pointer = jsonPointer(itemPath)
+
+		for j, key := range item.keys {
+			member := item.members[j]
+			if member.kind == ObjectValue || member.kind == ArrayValue {
+				e.addNested(member, key, appendPath(itemPath, key))
+				continue
+			}
+			r.addChild(key, member.value(), jsonPointer(appendPath(itemPath, key)))
+		}
+
+		// Null and empty elements hold no error, as well as elements of nested values only
+		if len(r.Children) == 0 {
+			continue
+		}
+
+		ps.ParsedErrors = append(ps.ParsedErrors, r)
+	}
+
+	return nil
+}
+
+func (e *sliceMapStringInterfaceError) transferLegacy(n *node, ps *ParsedErrors, parent string, path []string) {
 	r := ParsedError{}
 
-	for _, value := range strs {
-		tmp := make(map[string][]string)
-		for key, val := range value {
-			tmp[key] = []string{fmt.Sprintf("%v", val)}
+	for i, item := range n.items {
+		r.Children = make(map[string][]string)
+		r.ChildPointers = make(map[string][]string)
+		r.Values = make(map[string][]Value)
+		for j, key := range item.keys {
+			v := item.members[j].value()
+			r.Children[key] = []string{fmt.Sprintf("%v", v.Value)}
+			r.ChildPointers[key] = []string{jsonPointer(append(appendPath(path, strconv.Itoa(i)), key))}
+			r.Values[key] = []Value{v}
 		}
-		r.Children = tmp
 		r.Parent = parent
+		r.Pointer = jsonPointer(path)
 	}
 	ps.ParsedErrors = append(ps.ParsedErrors, r)
 }
@@ -230,173 +286,66 @@ func (e *sliceMapStringInterfaceError) transferTo(ps *ParsedErrors, parent strin
 type boolValue struct {
 	Value      bool
 	RawMessage json.RawMessage
+	nodeSource
 }
 
-func (e *boolValue) setRawMessage(m json.RawMessage) {
+func (e *boolValue) SetRawMessage(m json.RawMessage) {
 	e.RawMessage = m
 }
 
-func (e *boolValue) unmarshalJson() error {
+func (e *boolValue) Unmarshal() error {
 	return json.Unmarshal(e.RawMessage, &e.Value)
 }
 
-func (e *boolValue) transferTo(ps *ParsedErrors, parent string) {}
+func (e *boolValue) TransferTo(ps *ParsedErrors, parent string, path []string) error {
+	return nil
+}
 
 // Num struct and unmarshal
 type numValue struct {
-	Value      int
+	Value      float64
 	RawMessage json.RawMessage
+	nodeSource
 }
 
-func (e *numValue) setRawMessage(m json.RawMessage) {
+func (e *numValue) SetRawMessage(m json.RawMessage) {
 	e.RawMessage = m
 }
 
-func (e *numValue) unmarshalJson() error {
+func (e *numValue) Unmarshal() error {
 	return json.Unmarshal(e.RawMessage, &e.Value)
 }
 
-func (e *numValue) transferTo(ps *ParsedErrors, parent string) {}
-
-var typeRegistry = []string{
-	"boolValue",
-	"numValue",
-	"stringError",
-	"sliceStringError",
-	//"mapStringStringError",
-
-	"sliceMapStringInterfaceError",
-	"mapStringSliceInterfaceError",
-	"mapStringInterfaceError",
-}
-
-func makeInstanceStr(name string) ParsedErrorInterface {
-
-	switch name {
-	case "boolValue":
-		return &boolValue{}
-	case "numValue":
-		return &numValue{}
-	case "stringError":
-		return &stringError{}
-	case "sliceStringError":
-		return &sliceStringError{}
-		//case "mapStringStringError":
-		//	return &mapStringStringError{}
-	case "mapStringInterfaceError":
-		return &mapStringInterfaceError{}
-	case "mapStringSliceInterfaceError":
-		return &mapStringSliceInterfaceError{}
-	case "sliceMapStringInterfaceError":
-		return &sliceMapStringInterfaceError{}
-	default:
-		return nil
-	}
-}
-
-func batchExtract(s json.RawMessage, ps *ParsedErrors, parent string) error {
-
-	var mainError []error
-
-	debugMessage("Starting batch extractor..")
-
-	for _, name := range typeRegistry {
-
-		debugMessagef("Trying extract to %s\n", name)
-
-		parErr := makeInstanceStr(name)
-		parErr.setRawMessage(s)
-
-		err := parErr.unmarshalJson()
-		if err == nil {
-			parErr.transferTo(ps, parent)
-		} else {
-			mainError = append(mainError, err)
-		}
-	}
-
-	if len(mainError) > 0 {
-		for _, err := range mainError {
-			debugMessage(err.Error())
-		}
-		return errors.New("There are errors while unmarshaling")
-	}
-
+func (e *numValue) TransferTo(ps *ParsedErrors, parent string, path []string) error {
 	return nil
 }
 
-func batchCheck(s json.RawMessage, continueStructs []string) (error, bool) {
-
-	var mainError []error
-
-	for _, name := range typeRegistry {
-
-		parErr := makeInstanceStr(name)
-		parErr.setRawMessage(s)
-		err := parErr.unmarshalJson()
-
-		if err == nil {
-			if stringInSlice(name, continueStructs) {
-				return nil, true
-			}
-			debugMessage("nil, false given by " + name)
+// Shapes of built-in extractors, null is accepted by all of them like json.Unmarshal does
 
-			return nil, false
-		} else {
-			mainError = append(mainError, err)
-		}
-	}
-
-	if len(mainError) > 0 {
-		for _, err := range mainError {
-			debugMessage(err.Error())
-		}
-		return errors.New("There are errors while unmarshaling"), false
-	}
-
-	return nil, true
+func isBoolShape(n *node) bool {
+	return n.isNull() || n.kind == BoolValue
 }
 
-func batchCheckCallback(s json.RawMessage, funcMap map[string]func()) error {
-
-	var mainError []error
-
-	for _, name := range typeRegistry {
-
-		parErr := makeInstanceStr(name)
-		parErr.setRawMessage(s)
-		err := parErr.unmarshalJson()
-
-		fmt.Println("Check unmarshal to", name)
-		fmt.Println(err)
-		fmt.Printf("Errored value: %s\n", s)
+func isNumShape(n *node) bool {
+	return n.isNull() || n.kind == NumberValue
+}
 
-		if err == nil {
-			fmt.Println("Check if calback exists")
-			if f, ok := funcMap[name]; ok {
-				fmt.Println("Fire callback")
-				f()
-			}
+func isStringShape(n *node) bool {
+	return n.isNull() || n.kind == StringValue
+}
 
-			return nil
-		}
-	}
+func isSliceStringShape(n *node) bool {
+	return n.isNull() || n.kind == ArrayValue && n.itemsOf(StringValue)
+}
 
-	if len(mainError) > 0 {
-		for _, err := range mainError {
-			debugMessage(err.Error())
-		}
-		return errors.New("There are errors while unmarshaling")
-	}
+func isSliceMapShape(n *node) bool {
+	return n.isNull() || n.kind == ArrayValue && n.itemsOf(ObjectValue)
+}
 
-	return nil
+func isMapSliceShape(n *node) bool {
+	return n.isNull() || n.kind == ObjectValue && n.membersOf(ArrayValue)
 }
 
-func stringInSlice(a string, list []string) bool {
-	for _, b := range list {
-		if b == a {
-			return true
-		}
-	}
-	return false
+func isMapScalarShape(n *node) bool {
+	return n.isNull() || n.kind == ObjectValue && n.membersOf(StringValue, NumberValue, BoolValue)
 }
@@ -13,20 +13,20 @@ func TestUnmarshalString(t *testing.T) {
 	var unmarshaledErrorSuccess stringError
 	unmarshaledErrorSuccess.RawMessage = json.RawMessage(`"Unauthorized"`)
 
-	err := unmarshaledErrorSuccess.unmarshalJson()
+	err := unmarshaledErrorSuccess.Unmarshal()
 	assert.NoError(t, err)
 	assert.Equal(t, "Unauthorized", unmarshaledErrorSuccess.Error)
 
 	// transfer to parsed errors struct
 	parsedErrors := ParsedErrors{}
-	unmarshaledErrorSuccess.transferTo(&parsedErrors, "")
+	unmarshaledErrorSuccess.TransferTo(&parsedErrors, "", nil)
 	assert.Equal(t, "Unauthorized", parsedErrors.ParsedErrors[0].Messages[0])
 
 	// fault unmarshal
 	var unmarshaledErrorFault stringError
 	unmarshaledErrorFault.RawMessage = json.RawMessage(`{"error": "Unauthorized"}`)
 
-	err2 := unmarshaledErrorFault.unmarshalJson()
+	err2 := unmarshaledErrorFault.Unmarshal()
 	assert.Error(t, err2, "json: cannot unmarshal object into Go value of type string")
 }
 
@@ -35,14 +35,14 @@ func TestUnmarshalSliceString(t *testing.T) {
 	// success unmarshal
 	var unmarshaledErrorSuccess sliceStringError
 	unmarshaledErrorSuccess.RawMessage = json.RawMessage(`["Unauthorized", "Auth required"]`)
-	err := unmarshaledErrorSuccess.unmarshalJson()
+	err := unmarshaledErrorSuccess.Unmarshal()
 	assert.NoError(t, err)
 	assert.Equal(t, "Unauthorized", unmarshaledErrorSuccess.Error[0])
 	assert.Equal(t, "Auth required", unmarshaledErrorSuccess.Error[1])
 
 	// transfer to parsed errors struct
 	parsedErrors := ParsedErrors{}
-	unmarshaledErrorSuccess.transferTo(&parsedErrors, "parent")
+	unmarshaledErrorSuccess.TransferTo(&parsedErrors, "parent", []string{"parent"})
 	assert.Equal(t, "Unauthorized", parsedErrors.ParsedErrors[0].Messages[0])
 	assert.Equal(t, "Auth required", parsedErrors.ParsedErrors[0].Messages[1])
 	assert.Equal(t, "parent", parsedErrors.ParsedErrors[0].Parent)
@@ -51,7 +51,7 @@ func TestUnmarshalSliceString(t *testing.T) {
 	var unmarshaledErrorFault sliceStringError
 	unmarshaledErrorFault.RawMessage = json.RawMessage(`"Errors": ["Unauthorized","Auth required"]`)
 
-	err2 := unmarshaledErrorFault.unmarshalJson()
+	err2 := unmarshaledErrorFault.Unmarshal()
 	assert.Error(t, err2)
 }
 
@@ -61,13 +61,13 @@ func TestMapStringSliceInterfaceError(t *testing.T) {
 	var unmarshaledError mapStringSliceInterfaceError
 	unmarshaledError.RawMessage = json.RawMessage(`{"Errors": ["Unauthorized", "Auth required"]}`)
 
-	err := unmarshaledError.unmarshalJson()
+	err := unmarshaledError.Unmarshal()
 	assert.NoError(t, err)
 	assert.Equal(t, "Unauthorized", unmarshaledError.Error["Errors"][0])
 
 	// transfer to parsed errors struct
 	parsedErrors := ParsedErrors{}
-	unmarshaledError.transferTo(&parsedErrors, "TestParent")
+	unmarshaledError.TransferTo(&parsedErrors, "TestParent", []string{"TestParent"})
 
 	assert.Equal(t, "[TestParent][Errors] Auth required", parsedErrors.GetErrors()[0].Error())
 	assert.Equal(t, "[TestParent][Errors] Unauthorized", parsedErrors.GetErrors()[1].Error())
@@ -75,7 +75,7 @@ func TestMapStringSliceInterfaceError(t *testing.T) {
 	// fault unmarshal
 	var unmarshaledErrorFault mapStringSliceInterfaceError
 	unmarshaledErrorFault.RawMessage = json.RawMessage(`["Unauthorized","Auth required"]`)
-	err2 := unmarshaledErrorFault.unmarshalJson()
+	err2 := unmarshaledErrorFault.Unmarshal()
 	assert.Error(t, err2)
 }
 
@@ -85,14 +85,14 @@ func TestSliceMapStringInterfaceError(t *testing.T) {
 	var unmarshaledError sliceMapStringInterfaceError
 	unmarshaledError.RawMessage = json.RawMessage(`[{"secure": false, "name": "ADF", "value": "123"}]`)
 
-	err := unmarshaledError.unmarshalJson()
+	err := unmarshaledError.Unmarshal()
 	assert.NoError(t, err)
 	assert.Equal(t, false, unmarshaledError.Error[0]["secure"])
 	assert.Equal(t, "ADF", unmarshaledError.Error[0]["name"])
 
 	// transfer to parsed errors struct
 	parsedErrors := ParsedErrors{}
-	unmarshaledError.transferTo(&parsedErrors, "TestParent")
+	unmarshaledError.TransferTo(&parsedErrors, "TestParent", []string{"TestParent"})
 
 	assert.Equal(t, "[TestParent][name] ADF", parsedErrors.GetErrors()[0].Error())
 	assert.Equal(t, "[TestParent][secure] false", parsedErrors.GetErrors()[1].Error())
@@ -101,20 +101,26 @@ func TestSliceMapStringInterfaceError(t *testing.T) {
 	var unmarshaledErrorFault sliceMapStringInterfaceError
 	unmarshaledErrorFault.RawMessage = json.RawMessage(`{"huembuem" : [{"secure": false, "name": "ADF", "value": "123"}]}`)
 
-	err2 := unmarshaledErrorFault.unmarshalJson()
+	err2 := unmarshaledErrorFault.Unmarshal()
 	assert.Error(t, err2)
+
+	// null and empty elements give no errors
+	errs, err := Parse([]byte(`{"errors": [null, {"a": "b"}, {}]}`))
+	assert.NoError(t, err)
+	assert.Equal(t, 1, errs.GetCount())
+	assert.Equal(t, "/errors/1", errs.ParsedErrors[0].Pointer)
 }
 
 func TestBoolValue(t *testing.T) {
 
 	var unmarshaledError boolValue
 	unmarshaledError.RawMessage = json.RawMessage(`false`)
-	err := unmarshaledError.unmarshalJson()
+	err := unmarshaledError.Unmarshal()
 	assert.NoError(t, err)
 
 	var unmarshaledError2 boolValue
 	unmarshaledError2.RawMessage = json.RawMessage(`123`)
-	err2 := unmarshaledError2.unmarshalJson()
+	err2 := unmarshaledError2.Unmarshal()
 	assert.Error(t, err2)
 }
 
@@ -122,11 +128,20 @@ func TestBatchCheck(t *testing.T) {
 
 	file, e := ioutil.ReadFile("tests/example1.json")
 	assert.NoError(t, e)
-	parsedErrors := ParsedErrors{}
 
-	batchExtract(file, &parsedErrors, "")
-	//assert.NoError(t, err)
+	n, err := decodeNode(file)
+	assert.NoError(t, err)
+
+	// errors object of data is map of slices
+	parErr, name, ok := DefaultRegistry().match(n.member("data").member("errors"))
+	assert.True(t, ok)
+	assert.Equal(t, "mapStringSliceInterfaceError", name)
 
-	//assert.Equal(t, "Unauthorized", parsedErrors.ParsedErrors[0].Messages[0])
+	parsedErrors := ParsedErrors{}
+	assert.NoError(t, parErr.TransferTo(&parsedErrors, "errors", []string{"data", "errors"}))
+	assert.Equal(t, "Package spec not specified", parsedErrors.ParsedErrors[0].Children["PACKAGE_SPEC"][0])
 
+	// whole document matches no extractor
+	_, _, ok = DefaultRegistry().match(n)
+	assert.False(t, ok)
 }
@@ -0,0 +1,275 @@
+package go_json_errors_parser
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Problem holds members of RFC 7807 / RFC 9457 Problem Details object
+type Problem struct {
+	// URI reference identifying the problem type, "about:blank" when absent
+	Type     string
+	Title    string
+	Status   int
+	Detail   string
+	Instance string
+	// Members other than the standard ones, for example "invalid-params"
+	Extensions map[string]json.RawMessage
+}
+
+// Invalid parameter of problem extension "invalid-params"
+type problemInvalidParam struct {
+	Name   string `json:"name"`
+	Reason string `json:"reason"`
+}
+
+var problemMembers = []string{"type", "title", "status", "detail", "instance"}
+
+// Recognizes Problem Details object: detail string along with type or error status,
+// or title string along with numeric error status. Objects of type and title only,
+// like {"type": "book", "title": "Dune"}, and objects of status below 400 are too common
+// to be taken for problems.
+// Returns keys consumed by the recognized problem, other keys are walked as usual
+func (p *Parser) recognizeProblem(item *node, ps *ParsedErrors, parent string, path []string) ([]string, error) {
+
+	var problem Problem
+
+	var hasType, hasTitle, hasDetail bool
+	problem.Type, hasType = item.stringMember("type")
+	problem.Title, hasTitle = item.stringMember("title")
+	problem.Detail, hasDetail = item.stringMember("detail")
+	problem.Instance, _ = item.stringMember("instance")
+
+	hasStatus, numericStatus := false, false
+	if status := item.member("status"); status != nil {
+		switch status.kind {
+		case NumberValue:
+			if n, err := strconv.ParseFloat(status.text, 64); err == nil {
+				problem.Status, hasStatus, numericStatus = int(n), true, true
+			}
+		case StringValue:
+			if n, err := strconv.Atoi(status.text); err == nil {
+				problem.Status, hasStatus = n, true
+			}
+		}
+	}
+
+	errorStatus := problem.Status >= 400 && problem.Status <= 599
+	if hasStatus && !errorStatus {
+		return nil, nil
+	}
+	if !(hasDetail && (hasType || hasStatus)) && !(hasTitle && numericStatus) {
+		return nil, nil
+	}
+
+	p.traceFormat(path, "problem")
+
+	if !hasType {
+		problem.Type = "about:blank"
+	}
+
+	r := ParsedError{}
+	r.Parent = parent
+	r.Pointer = jsonPointer(path)
+	r.Status = problem.Status
+	r.Title = problem.Title
+
+	if hasDetail {
+		r.Messages = append(r.Messages, problem.Detail)
+		r.MessagePointers = append(r.MessagePointers, jsonPointer(appendPath(path, "detail")))
+	} else {
+		r.Messages = append(r.Messages, problem.Title)
+		r.MessagePointers = append(r.MessagePointers, jsonPointer(appendPath(path, "title")))
+	}
+
+	consumed := append([]string{}, problemMembers...)
+
+	for i, key := range item.keys {
+		value := item.members[i]
+		if stringInSlice(key, problemMembers) || value.isNull() {
+			continue
+		}
+		if problem.Extensions == nil {
+			problem.Extensions = make(map[string]json.RawMessage)
+		}
+		problem.Extensions[key] = value.raw()
+	}
+
+	var invalidParams []problemInvalidParam
+	if item.unmarshalMember("invalid-params", &invalidParams) {
+		for i, param := range invalidParams {
+			r.addChild(param.Name, stringValue(param.Reason),
+				jsonPointer(append(appendPath(path, "invalid-params"), strconv.Itoa(i), "reason")))
+		}
+
+		consumed = append(consumed, "invalid-params")
+	}
+
+	r.Problem = &problem
+	r.Extractor = "problem"
+	ps.ParsedErrors = append(ps.ParsedErrors, r)
+
+	return consumed, nil
+}
+
+// Content type of Problem Details documents
+const ProblemContentType = "application/problem+json"
+
+// Option of ToProblem rendering
+type ProblemOption func(*problemOptions)
+
+type problemOptions struct {
+	typ          string
+	title        string
+	instance     string
+	paramsMember string
+}
+
+// Sets problem type URI, "about:blank" by default
+func WithProblemType(uri string) ProblemOption {
+	return func(o *problemOptions) {
+		o.typ = uri
+	}
+}
+
+// Sets problem title, status text by default
+func WithProblemTitle(title string) ProblemOption {
+	return func(o *problemOptions) {
+		o.title = title
+	}
+}
+
+// Sets URI reference of the problem occurrence
+func WithProblemInstance(uri string) ProblemOption {
+	return func(o *problemOptions) {
+		o.instance = uri
+	}
+}
+
+// Sets member holding children, "invalid-params" by default, for example "errors"
+func WithParamsMember(name string) ProblemOption {
+	return func(o *problemOptions) {
+		o.paramsMember = name
+	}
+}
+
+// Invalid parameter rendered by ToProblem, pointer refers to the parsed document,
+// or is the Field of error, like JSON:API source pointer
+type problemParam struct {
+	Name    string `json:"name"`
+	Reason  string `json:"reason"`
+	Pointer string `json:"pointer,omitempty"`
+}
+
+// Renders parsed errors as Problem Details with given status: messages joined by "; " as detail
+// and children in "invalid-params" array of name, reason and JSON Pointer objects.
+// Messages of errors having Field are invalid params named and pointed by the Field.
+// Zero status takes status of parsed response, or 500 if errors were not parsed from response
+func (pe *ParsedErrors) ToProblem(status int, opts ...ProblemOption) *Problem {
+
+	options := problemOptions{typ: "about:blank", paramsMember: "invalid-params"}
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	if status == 0 {
+		status = http.StatusInternalServerError
+		if pe.Response != nil && pe.Response.StatusCode != 0 {
+			status = pe.Response.StatusCode
+		}
+	}
+
+	problem := &Problem{
+		Type:     options.typ,
+		Title:    options.title,
+		Status:   status,
+		Instance: options.instance,
+	}
+	if problem.Title == "" {
+		problem.Title = http.StatusText(status)
+	}
+
+	var msgs []string
+	var params []problemParam
+
+	for _, e := range pe.ParsedErrors {
+		// Messages of field, like JSON:API source pointer, are invalid params rather than detail
+		if e.Field != "" {
+			for _, msg := range e.Messages {
+				params = append(params, problemParam{Name: e.Field, Reason: msg, Pointer: e.Field})
+			}
+		} else {
+			msgs = append(msgs, e.Messages...)
+		}
+
+		var names []string
+		for name := range e.Children {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			for i, child := range e.Children[name] {
+				params = append(params, problemParam{Name: name, Reason: child, Pointer: e.childPointer(name, i)})
+			}
+		}
+	}
+
+	problem.Detail = strings.Join(msgs, "; ")
+
+	if len(params) > 0 {
+		raw, err := json.Marshal(params)
+		if err == nil {
+			problem.Extensions = map[string]json.RawMessage{options.paramsMember: raw}
+		}
+	}
+
+	return problem
+}
+
+// Marshals problem as Problem Details object, extensions along with standard members
+func (p Problem) MarshalJSON() ([]byte, error) {
+	members := make(map[string]interface{}, len(p.Extensions)+5)
+	for key, value := range p.Extensions {
+		members[key] = value
+	}
+
+	if p.Type != "" {
+		members["type"] = p.Type
+	}
+	if p.Title != "" {
+		members["title"] = p.Title
+	}
+	if p.Status != 0 {
+		members["status"] = p.Status
+	}
+	if p.Detail != "" {
+		members["detail"] = p.Detail
+	}
+	if p.Instance != "" {
+		members["instance"] = p.Instance
+	}
+
+	return json.Marshal(members)
+}
+
+// Writes problem with its status and application/problem+json content type
+func (p *Problem) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, err := json.Marshal(p)
+	if err != nil {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	status := p.Status
+	if status == 0 {
+		status = http.StatusInternalServerError
+	}
+
+	w.Header().Set("Content-Type", ProblemContentType)
+	w.WriteHeader(status)
+	w.Write(body)
+}
@@ -0,0 +1,122 @@
+package go_json_errors_parser
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRecognizeProblem(t *testing.T) {
+
+	// title and status without type
+	errs, err := Parse([]byte(`[{"title": "Not Found", "status": 404}]`))
+	assert.NoError(t, err)
+	assert.Equal(t, 1, errs.GetCount())
+	assert.Equal(t, "about:blank", errs.ParsedErrors[0].Problem.Type)
+	assert.Equal(t, 404, errs.ParsedErrors[0].Problem.Status)
+	assert.Equal(t, "Not Found", errs.ParsedErrors[0].Messages[0])
+	assert.Equal(t, "/0/title", errs.ParsedErrors[0].MessagePointers[0])
+
+	// extension errors are walked as usual, detail is not taken twice
+	errs, err = Parse([]byte(`{"type": "about:blank", "detail": "Validation error", "errors": {"name": "required"}}`))
+	assert.NoError(t, err)
+	assert.Equal(t, 2, errs.GetCount())
+	assert.Equal(t, "Validation error", errs.ParsedErrors[0].Messages[0])
+	assert.Equal(t, "required", errs.ParsedErrors[1].Children["name"][0])
+	assert.Nil(t, errs.ParsedErrors[1].Problem)
+
+	// title alone is not a problem
+	errs, err = Parse([]byte(`{"title": "Book", "pages": 100}`))
+	assert.NoError(t, err)
+	assert.Equal(t, false, errs.IsErrors())
+
+	// neither are type and title of ordinary objects
+	errs, err = Parse([]byte(`{"items": [{"type": "book", "title": "Dune"}, {"type": "book", "title": "Emma"}]}`))
+	assert.NoError(t, err)
+	assert.Equal(t, false, errs.IsErrors())
+
+	// nor objects of successful status
+	errs, err = Parse([]byte(`{"items": [{"title": "Order shipped", "status": 200}, {"detail": "Paid", "status": 201}]}`))
+	assert.NoError(t, err)
+	assert.Equal(t, false, errs.IsErrors())
+}
+
+func TestToProblem(t *testing.T) {
+	errs, err := Parse([]byte(`{"message": "Validation error", "data": {"errors": {"name": ["is required", "is too short"]}}}`))
+	assert.NoError(t, err)
+
+	problem := errs.ToProblem(http.StatusUnprocessableEntity, WithProblemInstance("/pipelines/1"))
+
+	assert.Equal(t, "about:blank", problem.Type)
+	assert.Equal(t, "Unprocessable Entity", problem.Title)
+	assert.Equal(t, 422, problem.Status)
+	assert.Equal(t, "Validation error", problem.Detail)
+	assert.Equal(t, "/pipelines/1", problem.Instance)
+
+	body, err := json.Marshal(problem)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{
+		"type": "about:blank",
+		"title": "Unprocessable Entity",
+		"status": 422,
+		"detail": "Validation error",
+		"instance": "/pipelines/1",
+		"invalid-params": [
+			{"name": "name", "reason": "is required", "pointer": "/data/errors/name/0"},
+			{"name": "name", "reason": "is too short", "pointer": "/data/errors/name/1"}
+		]
+	}`, string(body))
+
+	// rendered problem is recognized back
+	errs, err = Parse(body)
+	assert.NoError(t, err)
+	assert.Equal(t, "Validation error", errs.ParsedErrors[0].Messages[0])
+	assert.Equal(t, []string{"is required", "is too short"}, errs.ParsedErrors[0].Children["name"])
+	assert.Equal(t, StringValue, errs.ParsedErrors[0].Values["name"][1].Kind)
+	assert.Equal(t, "is too short", errs.ParsedErrors[0].Values["name"][1].Value)
+}
+
+func TestToProblemOptions(t *testing.T) {
+	errs := &ParsedErrors{
+		ParsedErrors: []ParsedError{{Children: map[string][]string{"title": {"is empty"}}}},
+		Response:     &ResponseInfo{StatusCode: 400},
+	}
+
+	problem := errs.ToProblem(0, WithProblemType("https://example.com/invalid"), WithProblemTitle("Invalid book"), WithParamsMember("errors"))
+
+	assert.Equal(t, 400, problem.Status)
+	assert.Equal(t, "https://example.com/invalid", problem.Type)
+	assert.Equal(t, "Invalid book", problem.Title)
+	assert.Equal(t, "", problem.Detail)
+	assert.JSONEq(t, `[{"name": "title", "reason": "is empty", "pointer": "/title"}]`, string(problem.Extensions["errors"]))
+
+	assert.Equal(t, 500, (&ParsedErrors{}).ToProblem(0).Status)
+}
+
+func TestToProblemFields(t *testing.T) {
+	errs, err := Parse([]byte(`{"errors": [
+		{"status": "422", "source": {"pointer": "/data/attributes/title"}, "detail": "is blank"},
+		{"status": "422", "detail": "Invalid book"}
+	]}`))
+	assert.NoError(t, err)
+
+	problem := errs.ToProblem(http.StatusUnprocessableEntity)
+
+	assert.Equal(t, "Invalid book", problem.Detail)
+	assert.JSONEq(t, `[{"name": "/data/attributes/title", "reason": "is blank", "pointer": "/data/attributes/title"}]`,
+		string(problem.Extensions["invalid-params"]))
+}
+
+func TestProblemServeHTTP(t *testing.T) {
+	errs, err := Parse([]byte(`{"error": "Book not found"}`))
+	assert.NoError(t, err)
+
+	recorder := httptest.NewRecorder()
+	errs.ToProblem(http.StatusNotFound).ServeHTTP(recorder, httptest.NewRequest("GET", "/books/1", nil))
+
+	assert.Equal(t, 404, recorder.Code)
+	assert.Equal(t, "application/problem+json", recorder.Header().Get("Content-Type"))
+	assert.JSONEq(t, `{"type": "about:blank", "title": "Not Found", "status": 404, "detail": "Book not found"}`, recorder.Body.String())
+}
@@ -0,0 +1,155 @@
+package go_json_errors_parser
+
+import (
+	"sort"
+	"sync"
+)
+
+// Returns new instance of extractor, called for every checked value
+type ExtractorFactory func() Extractor
+
+type registryEntry struct {
+	name     string
+	priority int
+	factory  ExtractorFactory
+	// Reports whether decoded value may be unmarshaled by extractor, nil accepts any value
+	accepts func(n *node) bool
+}
+
+// Registry holds extractors tried on json values, in priority order
+type Registry struct {
+	mu      sync.RWMutex
+	entries []registryEntry
+}
+
+// Returns empty registry
+func NewRegistry() *Registry {
+	return &Registry{}
+}
+
+// Returns new registry with built-in extractors.
+// Built-in extractors have priorities from 100 to 700
+func DefaultRegistry() *Registry {
+	r := primitiveRegistry()
+
+	r.register("googleStatusError", 380, func() Extractor { return &googleStatusError{} }, isGoogleStatusShape)
+	r.register("jsonAPIError", 350, func() Extractor { return &jsonAPIError{} }, isJSONAPIShape)
+
+	return r
+}
+
+// Returns registry with extractors of plain json shapes, used to detect shape of walked values
+func primitiveRegistry() *Registry {
+	r := NewRegistry()
+
+	r.register("boolValue", 700, func() Extractor { return &boolValue{} }, isBoolShape)
+	r.register("numValue", 600, func() Extractor { return &numValue{} }, isNumShape)
+	r.register("stringError", 500, func() Extractor { return &stringError{} }, isStringShape)
+	r.register("sliceStringError", 400, func() Extractor { return &sliceStringError{} }, isSliceStringShape)
+	r.register("sliceMapStringInterfaceError", 300, func() Extractor { return &sliceMapStringInterfaceError{} }, isSliceMapShape)
+	r.register("mapStringSliceInterfaceError", 200, func() Extractor { return &mapStringSliceInterfaceError{} }, isMapSliceShape)
+	r.register("mapStringInterfaceError", 100, func() Extractor { return &mapStringInterfaceError{} }, isMapScalarShape)
+
+	return r
+}
+
+// Registers extractor under given name, replacing extractor already registered with the same name.
+// Extractors with higher priority are tried first, equal priorities keep registration order
+func (r *Registry) Register(name string, priority int, factory ExtractorFactory) {
+	r.register(name, priority, factory, nil)
+}
+
+// Registers extractor along with check of decoded value, so values of other shapes
+// are skipped without unmarshaling
+func (r *Registry) register(name string, priority int, factory ExtractorFactory, accepts func(n *node) bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	entry := registryEntry{name: name, priority: priority, factory: factory, accepts: accepts}
+	entries := append(r.without(name), entry)
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].priority > entries[j].priority
+	})
+
+	r.entries = entries
+}
+
+// Removes extractor registered under given name
+func (r *Registry) Unregister(name string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.entries = r.without(name)
+}
+
+// Returns names of registered extractors in the order they are tried
+func (r *Registry) Names() []string {
+	var names []string
+	for _, entry := range r.snapshot() {
+		names = append(names, entry.name)
+	}
+	return names
+}
+
+// Entries are copied on write, so snapshots taken by running parsers stay untouched
+func (r *Registry) without(name string) []registryEntry {
+	entries := make([]registryEntry, 0, len(r.entries)+1)
+	for _, entry := range r.entries {
+		if entry.name != name {
+			entries = append(entries, entry)
+		}
+	}
+	return entries
+}
+
+func (r *Registry) snapshot() []registryEntry {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.entries
+}
+
+// Returns first extractor from registry the decoded value can be unmarshaled with.
+// Built-in extractors take the decoded value as is, others unmarshal its json
+func (r *Registry) match(n *node) (Extractor, string, bool) {
+
+	for _, entry := range r.snapshot() {
+
+		if entry.accepts != nil && !entry.accepts(n) {
+			continue
+		}
+
+		parErr := entry.factory()
+		if e, ok := parErr.(nodeExtractor); ok && entry.accepts != nil {
+			e.setNode(n)
+			return parErr, entry.name, true
+		}
+
+		parErr.SetRawMessage(n.raw())
+		if err := parErr.Unmarshal(); err == nil {
+			return parErr, entry.name, true
+		}
+	}
+
+	return nil, "", false
+}
+
+// Returns name of the first shape decoded value has, empty if none
+func shapeOf(n *node) string {
+	for _, entry := range shapes.snapshot() {
+		if entry.accepts(n) {
+			return entry.name
+		}
+	}
+	return ""
+}
+
+func stringInSlice(a string, list []string) bool {
+	for _, b := range list {
+		if b == a {
+			return true
+		}
+	}
+	return false
+}
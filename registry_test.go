@@ -0,0 +1,77 @@
+package go_json_errors_parser
+
+import (
+	"encoding/json"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+// Takes "code: message" strings as errors with code as child name
+type codeMessageError struct {
+	Code       string
+	Message    string
+	RawMessage json.RawMessage
+}
+
+func (e *codeMessageError) SetRawMessage(m json.RawMessage) {
+	e.RawMessage = m
+}
+
+func (e *codeMessageError) Unmarshal() error {
+	var s string
+	if err := json.Unmarshal(e.RawMessage, &s); err != nil {
+		return err
+	}
+
+	parts := strings.SplitN(s, ": ", 2)
+	if len(parts) != 2 {
+		return errors.New("No code in message")
+	}
+
+	e.Code, e.Message = parts[0], parts[1]
+	return nil
+}
+
+func (e *codeMessageError) TransferTo(ps *ParsedErrors, parent string, path []string) error {
+	r := ParsedError{}
+	r.Parent = parent
+	r.Pointer = jsonPointer(path)
+	r.Children = map[string][]string{e.Code: {e.Message}}
+	ps.ParsedErrors = append(ps.ParsedErrors, r)
+	return nil
+}
+
+func TestRegistryRegister(t *testing.T) {
+	r := NewRegistry()
+	r.Register("low", 1, func() Extractor { return &stringError{} })
+	r.Register("high", 10, func() Extractor { return &stringError{} })
+	r.Register("middle", 5, func() Extractor { return &stringError{} })
+	r.Register("low2", 1, func() Extractor { return &stringError{} })
+	assert.Equal(t, []string{"high", "middle", "low", "low2"}, r.Names())
+
+	// replace by name
+	r.Register("low", 20, func() Extractor { return &stringError{} })
+	assert.Equal(t, []string{"low", "high", "middle", "low2"}, r.Names())
+
+	r.Unregister("middle")
+	assert.Equal(t, []string{"low", "high", "low2"}, r.Names())
+}
+
+func TestParserWithRegistry(t *testing.T) {
+	doc := []byte(`{"error": "E42: Unauthorized", "code": 401}`)
+
+	registry := DefaultRegistry()
+	registry.Register("codeMessageError", 1000, func() Extractor { return &codeMessageError{} })
+
+	errs, err := NewParser(WithRegistry(registry)).Parse(doc)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, errs.GetCount())
+	assert.Equal(t, "Unauthorized", errs.ParsedErrors[0].Children["E42"][0])
+
+	// default parser is not affected
+	errs, err = Parse(doc)
+	assert.NoError(t, err)
+	assert.Equal(t, "E42: Unauthorized", errs.ParsedErrors[0].Messages[0])
+}
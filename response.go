@@ -0,0 +1,188 @@
+package go_json_errors_parser
+
+import (
+	"bytes"
+	"encoding/json"
+	"html"
+	"io"
+	"io/ioutil"
+	"mime"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Default limit of response body read by ParseResponse
+const DefaultMaxBodySize = 10 << 20
+
+// Longest message taken from non-json response body
+const maxTextMessageLength = 1024
+
+// ResponseInfo holds HTTP response details relevant to errors
+type ResponseInfo struct {
+	StatusCode  int
+	ContentType string
+	// Delay from Retry-After header, zero if absent
+	RetryAfter time.Duration `json:",omitempty"`
+	// Value of X-Request-Id header
+	RequestID string `json:",omitempty"`
+}
+
+// Sets limit of response body read by ParseResponse
+func WithMaxBodySize(n int64) Option {
+	return func(p *Parser) {
+		p.maxBodySize = n
+	}
+}
+
+// Parses response body with default parser
+func ParseResponse(resp *http.Response) (*ParsedErrors, error) {
+	return defaultParser.ParseResponse(resp)
+}
+
+// Reads response body up to the parser body size limit and parses errors in it.
+// Json bodies are parsed as usual, other bodies of error responses give single error
+// with html title, plain text or status text as message.
+// Body is restored, so it can be read again
+func (p *Parser) ParseResponse(resp *http.Response) (*ParsedErrors, error) {
+
+	body, err := p.readBody(resp)
+	if err != nil {
+		return &ParsedErrors{Response: newResponseInfo(resp)}, err
+	}
+
+	return p.parseBody(resp, body)
+}
+
+func newResponseInfo(resp *http.Response) *ResponseInfo {
+	return &ResponseInfo{
+		StatusCode:  resp.StatusCode,
+		ContentType: resp.Header.Get("Content-Type"),
+		RetryAfter:  retryAfter(resp.Header),
+		RequestID:   resp.Header.Get("X-Request-Id"),
+	}
+}
+
+// Parses body already read from response
+func (p *Parser) parseBody(resp *http.Response, body []byte) (*ParsedErrors, error) {
+
+	info := newResponseInfo(resp)
+
+	var errs *ParsedErrors
+	var err error
+
+	if len(bytes.TrimSpace(body)) > 0 && (isJSONContentType(info.ContentType) || json.Valid(body)) {
+		errs, err = p.Parse(body)
+	} else {
+		errs = &ParsedErrors{}
+		if resp.StatusCode >= 400 {
+			errs.ParsedErrors = append(errs.ParsedErrors, ParsedError{
+				Messages:  []string{textMessage(info.ContentType, body, resp.StatusCode)},
+				Status:    resp.StatusCode,
+				Extractor: "text",
+			})
+			p.categorize(errs.ParsedErrors)
+		}
+	}
+
+	errs.Response = info
+
+	return errs, err
+}
+
+// Reads body up to limit and puts read bytes back in front of the rest of the body
+func (p *Parser) readBody(resp *http.Response) ([]byte, error) {
+	if resp.Body == nil {
+		// Custom RoundTrippers may return responses without body, as http.Client tolerates them
+		resp.Body = http.NoBody
+		return nil, nil
+	}
+
+	body, err := ioutil.ReadAll(io.LimitReader(resp.Body, p.maxBodySize+1))
+
+	resp.Body = &replayBody{
+		Reader: io.MultiReader(bytes.NewReader(body), resp.Body),
+		Closer: resp.Body,
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if int64(len(body)) > p.maxBodySize {
+		return nil, &LimitExceededError{Limit: "body size", Max: p.maxBodySize}
+	}
+
+	return body, nil
+}
+
+type replayBody struct {
+	io.Reader
+	io.Closer
+}
+
+// Returns true for application/json, text/json and structured syntax suffix types like application/problem+json
+func isJSONContentType(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+
+	return mediaType == "application/json" || mediaType == "text/json" || strings.HasSuffix(mediaType, "+json")
+}
+
+var htmlTitleRegexp = regexp.MustCompile(`(?is)<title[^>]*>(.*?)</title>`)
+
+// Returns html title, plain text or status text of non-json body
+func textMessage(contentType string, body []byte, statusCode int) string {
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+
+	if mediaType == "text/html" {
+		if match := htmlTitleRegexp.FindSubmatch(body); match != nil {
+			if title := strings.TrimSpace(html.UnescapeString(string(match[1]))); title != "" {
+				return title
+			}
+		}
+	} else if text := strings.TrimSpace(string(body)); text != "" && !strings.HasPrefix(text, "<") {
+		if len(text) > maxTextMessageLength {
+			text = text[:maxTextMessageLength]
+			// Don't leave cut rune at the end
+			for !utf8.ValidString(text) {
+				text = text[:len(text)-1]
+			}
+		}
+		return text
+	}
+
+	return http.StatusText(statusCode)
+}
+
+// Parses Retry-After header given in seconds or as HTTP date
+func retryAfter(header http.Header) time.Duration {
+	value := header.Get("Retry-After")
+	if value == "" {
+		return 0
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+
+	at, err := http.ParseTime(value)
+	if err != nil {
+		return 0
+	}
+
+	now := time.Now()
+	if date, err := http.ParseTime(header.Get("Date")); err == nil {
+		now = date
+	}
+
+	if delay := at.Sub(now); delay > 0 {
+		return delay
+	}
+	return 0
+}
@@ -0,0 +1,85 @@
+package go_json_errors_parser
+
+import (
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newResponse(statusCode int, contentType string, body string) *http.Response {
+	header := http.Header{}
+	header.Set("Content-Type", contentType)
+
+	return &http.Response{
+		StatusCode: statusCode,
+		Header:     header,
+		Body:       ioutil.NopCloser(strings.NewReader(body)),
+	}
+}
+
+func TestParseResponseJSON(t *testing.T) {
+	resp := newResponse(401, "application/json; charset=utf-8", `{"error": "Unauthorized", "code": 401}`)
+	resp.Header.Set("Retry-After", "120")
+	resp.Header.Set("X-Request-Id", "req-1")
+
+	errs, err := ParseResponse(resp)
+	assert.NoError(t, err)
+	assert.Equal(t, "Unauthorized", errs.ParsedErrors[0].Messages[0])
+	assert.Equal(t, 401, errs.Response.StatusCode)
+	assert.Equal(t, 2*time.Minute, errs.Response.RetryAfter)
+	assert.Equal(t, "req-1", errs.Response.RequestID)
+
+	// body is restored
+	body, err := ioutil.ReadAll(resp.Body)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"error": "Unauthorized", "code": 401}`, string(body))
+}
+
+func TestParseResponseProblem(t *testing.T) {
+	resp := newResponse(404, "application/problem+json", `{"title": "Not Found", "status": 404}`)
+
+	errs, err := ParseResponse(resp)
+	assert.NoError(t, err)
+	assert.Equal(t, 404, errs.ParsedErrors[0].Problem.Status)
+}
+
+func TestParseResponseFallbacks(t *testing.T) {
+	errs, err := ParseResponse(newResponse(502, "text/html", `<html><head><title>502 Bad Gateway</title></head></html>`))
+	assert.NoError(t, err)
+	assert.Equal(t, "502 Bad Gateway", errs.ParsedErrors[0].Messages[0])
+	assert.Equal(t, 502, errs.ParsedErrors[0].Status)
+
+	errs, err = ParseResponse(newResponse(503, "text/plain", "upstream connect error\n"))
+	assert.NoError(t, err)
+	assert.Equal(t, "upstream connect error", errs.ParsedErrors[0].Messages[0])
+
+	errs, err = ParseResponse(newResponse(500, "application/json", ""))
+	assert.NoError(t, err)
+	assert.Equal(t, "Internal Server Error", errs.ParsedErrors[0].Messages[0])
+
+	errs, err = ParseResponse(newResponse(200, "text/plain", "OK"))
+	assert.NoError(t, err)
+	assert.Equal(t, false, errs.IsErrors())
+}
+
+func TestParseResponseBodyLimit(t *testing.T) {
+	resp := newResponse(400, "application/json", `{"error": "Bad Request"}`)
+
+	_, err := NewParser(WithMaxBodySize(10)).ParseResponse(resp)
+	assert.IsType(t, &LimitExceededError{}, err)
+
+	// body is restored even if it is too large
+	body, _ := ioutil.ReadAll(resp.Body)
+	assert.Equal(t, `{"error": "Bad Request"}`, string(body))
+}
+
+func TestRetryAfterDate(t *testing.T) {
+	header := http.Header{}
+	header.Set("Date", "Wed, 21 Oct 2015 07:28:00 GMT")
+	header.Set("Retry-After", "Wed, 21 Oct 2015 07:29:30 GMT")
+
+	assert.Equal(t, 90*time.Second, retryAfter(header))
+}
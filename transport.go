@@ -0,0 +1,203 @@
+package go_json_errors_parser
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"strings"
+)
+
+// Rule decides whether successful response holds errors
+type Rule func(resp *http.Response, body []byte) bool
+
+// Returns rule matching json object bodies having top-level member equal to value,
+// for example MemberEquals("success", false)
+func MemberEquals(name string, value interface{}) Rule {
+	// Normalize value to types produced by json.Unmarshal
+	var expected interface{}
+	if raw, err := json.Marshal(value); err == nil {
+		json.Unmarshal(raw, &expected)
+	}
+
+	return func(resp *http.Response, body []byte) bool {
+		var object map[string]interface{}
+		if err := json.Unmarshal(body, &object); err != nil {
+			return false
+		}
+
+		actual, ok := object[name]
+		return ok && reflect.DeepEqual(actual, expected)
+	}
+}
+
+// ResponseError is returned by Transport for inspected error responses
+type ResponseError struct {
+	StatusCode int
+	Errors     *ParsedErrors
+	// Response with body closed, or readable again if transport keeps body
+	Response *http.Response
+	// Original body, if transport keeps body
+	Body []byte
+	// Error of reading or parsing body, Errors hold errors found so far
+	ParseError error
+}
+
+func (e *ResponseError) Error() string {
+	msg := fmt.Sprintf("jerrparser: response status %d", e.StatusCode)
+
+	if e.Errors != nil && e.Errors.IsErrors() {
+		var errs []string
+		for _, err := range e.Errors.GetErrors() {
+			errs = append(errs, err.Error())
+		}
+		msg += ": " + strings.Join(errs, "; ")
+	}
+
+	return msg
+}
+
+// Returns parsed errors and body parsing error, so errors.Is and errors.As look into them
+func (e *ResponseError) Unwrap() []error {
+	var errs []error
+	if e.Errors != nil {
+		errs = append(errs, e.Errors)
+	}
+	if e.ParseError != nil {
+		errs = append(errs, e.ParseError)
+	}
+	return errs
+}
+
+// Transport is http.RoundTripper turning error responses into *ResponseError.
+// Non-2xx responses are inspected by default, 2xx responses only if one of the rules matches
+type Transport struct {
+	// Transport used to make requests, http.DefaultTransport if nil
+	Base http.RoundTripper
+
+	parser   *Parser
+	inspect  func(statusCode int) bool
+	rules    []Rule
+	keepBody bool
+}
+
+// Option configures Transport
+type TransportOption func(*Transport)
+
+// Sets parser used for response bodies
+func WithParser(p *Parser) TransportOption {
+	return func(t *Transport) {
+		t.parser = p
+	}
+}
+
+// Sets status codes of responses inspected regardless of rules
+func WithInspectStatus(inspect func(statusCode int) bool) TransportOption {
+	return func(t *Transport) {
+		t.inspect = inspect
+	}
+}
+
+// Adds rules, matched against responses not inspected by status code
+func WithRules(rules ...Rule) TransportOption {
+	return func(t *Transport) {
+		t.rules = append(t.rules, rules...)
+	}
+}
+
+// Keeps original body in ResponseError and its response
+func WithKeepBody(keep bool) TransportOption {
+	return func(t *Transport) {
+		t.keepBody = keep
+	}
+}
+
+// Returns transport wrapping base, http.DefaultTransport if nil
+func NewTransport(base http.RoundTripper, opts ...TransportOption) *Transport {
+	t := &Transport{
+		Base:    base,
+		parser:  defaultParser,
+		inspect: isErrorStatus,
+	}
+
+	for _, opt := range opts {
+		opt(t)
+	}
+
+	return t
+}
+
+// Inspects non-2xx responses
+func isErrorStatus(statusCode int) bool {
+	return statusCode < 200 || statusCode > 299
+}
+
+// Zero value Transport uses http.DefaultTransport, default parser and inspects non-2xx responses
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := t.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	parser := t.parser
+	if parser == nil {
+		parser = defaultParser
+	}
+	inspect := t.inspect
+	if inspect == nil {
+		inspect = isErrorStatus
+	}
+
+	resp, err := base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	inspected := inspect(resp.StatusCode)
+	if !inspected && len(t.rules) == 0 {
+		return resp, nil
+	}
+
+	// Body stays readable after it is read up to the parser limit
+	body, readErr := parser.readBody(resp)
+	if readErr != nil && !inspected {
+		// Rules are not matched against unreadable bodies
+		return resp, nil
+	}
+
+	if !inspected {
+		for _, rule := range t.rules {
+			if rule(resp, body) {
+				inspected = true
+				break
+			}
+		}
+	}
+
+	if !inspected {
+		return resp, nil
+	}
+
+	resp.Body.Close()
+
+	respErr := &ResponseError{
+		StatusCode: resp.StatusCode,
+		Response:   resp,
+	}
+
+	if readErr != nil {
+		respErr.Errors = &ParsedErrors{Response: newResponseInfo(resp)}
+		respErr.ParseError = readErr
+		return nil, respErr
+	}
+
+	respErr.Errors, respErr.ParseError = parser.parseBody(resp, body)
+
+	if t.keepBody {
+		respErr.Body = body
+		resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+	}
+
+	return nil, respErr
+}
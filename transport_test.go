@@ -0,0 +1,121 @@
+package go_json_errors_parser
+
+import (
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+func newTestServer(statusCode int, body string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(statusCode)
+		w.Write([]byte(body))
+	}))
+}
+
+func TestTransportErrorResponse(t *testing.T) {
+	server := newTestServer(422, `{"errors": {"name": "required"}}`)
+	defer server.Close()
+
+	client := &http.Client{Transport: NewTransport(nil, WithKeepBody(true))}
+
+	_, err := client.Get(server.URL)
+	assert.Error(t, err)
+	assert.IsType(t, &url.Error{}, err)
+
+	respErr, ok := err.(*url.Error).Err.(*ResponseError)
+	assert.True(t, ok)
+	assert.Equal(t, 422, respErr.StatusCode)
+	assert.Equal(t, "required", respErr.Errors.ParsedErrors[0].Children["name"][0])
+	assert.Equal(t, `{"errors": {"name": "required"}}`, string(respErr.Body))
+	assert.Equal(t, "jerrparser: response status 422: [][name] required", respErr.Error())
+
+	body, _ := ioutil.ReadAll(respErr.Response.Body)
+	assert.Equal(t, `{"errors": {"name": "required"}}`, string(body))
+}
+
+func TestTransportZeroValue(t *testing.T) {
+	server := newTestServer(404, `{"error": "No such book"}`)
+	defer server.Close()
+
+	client := &http.Client{Transport: &Transport{Base: http.DefaultTransport}}
+
+	_, err := client.Get(server.URL)
+	respErr, ok := err.(*url.Error).Err.(*ResponseError)
+	assert.True(t, ok)
+	assert.Equal(t, 404, respErr.StatusCode)
+	assert.Equal(t, "No such book", respErr.Errors.ParsedErrors[0].Messages[0])
+
+	// successful response passes
+	success := newTestServer(200, `{"name": "Dune"}`)
+	defer success.Close()
+
+	resp, err := (&http.Client{Transport: &Transport{}}).Get(success.URL)
+	assert.NoError(t, err)
+	assert.Equal(t, 200, resp.StatusCode)
+	resp.Body.Close()
+}
+
+type roundTripperFunc func(req *http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestTransportNilBody(t *testing.T) {
+	base := roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		return &http.Response{StatusCode: 500, Header: http.Header{}, Request: req}, nil
+	})
+
+	req, _ := http.NewRequest("GET", "http://example.com/books", nil)
+	_, err := NewTransport(base).RoundTrip(req)
+
+	respErr, ok := err.(*ResponseError)
+	assert.True(t, ok)
+	assert.Equal(t, 500, respErr.StatusCode)
+	assert.NoError(t, respErr.ParseError)
+}
+
+func TestTransportRules(t *testing.T) {
+	server := newTestServer(200, `{"success": false, "error": "Quota exceeded"}`)
+	defer server.Close()
+
+	// successful response passes without rules
+	client := &http.Client{Transport: NewTransport(nil)}
+	resp, err := client.Get(server.URL)
+	assert.NoError(t, err)
+	resp.Body.Close()
+
+	// rule not matching keeps body readable
+	client = &http.Client{Transport: NewTransport(nil, WithRules(MemberEquals("success", 0)))}
+	resp, err = client.Get(server.URL)
+	assert.NoError(t, err)
+	body, _ := ioutil.ReadAll(resp.Body)
+	assert.Equal(t, `{"success": false, "error": "Quota exceeded"}`, string(body))
+
+	client = &http.Client{Transport: NewTransport(nil, WithRules(MemberEquals("success", false)))}
+	_, err = client.Get(server.URL)
+
+	respErr, ok := err.(*url.Error).Err.(*ResponseError)
+	assert.True(t, ok)
+	assert.Equal(t, "Quota exceeded", respErr.Errors.ParsedErrors[0].Messages[0])
+	assert.Nil(t, respErr.Body)
+}
+
+func TestTransportInspectStatus(t *testing.T) {
+	server := newTestServer(404, `{"error": "Not Found"}`)
+	defer server.Close()
+
+	client := &http.Client{Transport: NewTransport(nil, WithInspectStatus(func(statusCode int) bool {
+		return statusCode >= 500
+	}))}
+
+	resp, err := client.Get(server.URL)
+	assert.NoError(t, err)
+	assert.Equal(t, 404, resp.StatusCode)
+	resp.Body.Close()
+}
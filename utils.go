@@ -1,17 +1,9 @@
 package go_json_errors_parser
 
 import (
-	"fmt"
-	"github.com/hokaccha/go-prettyjson"
-	"os"
+	"strings"
 )
 
-func checkErr(err error) {
-	if err != nil {
-		panic(err)
-	}
-}
-
 func trimQuotes(s string) string {
 	if len(s) > 0 && s[0] == '"' {
 		s = s[1:]
@@ -22,27 +14,23 @@ func trimQuotes(s string) string {
 	return s
 }
 
-func prettyPrintStruct(strct interface{}) {
-	s, _ := prettyjson.Marshal(strct)
-	fmt.Println(string(s))
+// Returns copy of path with key appended, so sibling branches don't share backing array
+func appendPath(path []string, key string) []string {
+	p := make([]string, len(path), len(path)+1)
+	copy(p, path)
+	return append(p, key)
 }
 
-const DebugEnvVarName = "GOJSONPARSER_DEBUG"
-
-func debugMessage(string string) {
-	if os.Getenv(DebugEnvVarName) == "1" {
-		fmt.Println(string)
-	}
-}
+var pointerEscaper = strings.NewReplacer("~", "~0", "/", "~1")
 
-func debugMessagef(format string, i interface{}) {
-	if os.Getenv(DebugEnvVarName) == "1" {
-		fmt.Printf(format, i)
+// Formats path as RFC 6901 JSON Pointer
+func jsonPointer(path []string) string {
+	pointer := ""
+	for _, key := range path {
+		pointer += "/" + pointerEscaper.Replace(key)
 	}
+	return pointer
 }
 
-func debugStruct(strct interface{}) {
-	if os.Getenv(DebugEnvVarName) == "1" {
-		prettyPrintStruct(strct)
-	}
-}
+// Deprecated: debug output is no longer enabled by environment, use WithLogger
+const DebugEnvVarName = "GOJSONPARSER_DEBUG"
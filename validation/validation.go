@@ -0,0 +1,127 @@
+// Package validation converts parsed errors to field errors implementing FieldError
+// of github.com/go-playground/validator, so upstream validation errors are rendered
+// by the same code and templates as local ones.
+package validation
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	ut "github.com/go-playground/universal-translator"
+	"github.com/go-playground/validator/v10"
+	jerrparser "github.com/inhuman/go-json-errors-parser"
+)
+
+// FieldError is validator.FieldError along with message of upstream error
+type FieldError interface {
+	validator.FieldError
+	// Message of upstream error
+	Message() string
+}
+
+var _ validator.FieldError = (*fieldError)(nil)
+
+// ValidationErrors is list of field errors, convertible to validator.ValidationErrors.
+// Elements are FieldError. Translate them with ValidationErrors.Translate, as
+// validator.ValidationErrors.Translate accepts errors made by validator only
+type ValidationErrors []validator.FieldError
+
+func (ve ValidationErrors) Error() string {
+	var msgs []string
+	for _, e := range ve {
+		msgs = append(msgs, e.Error())
+	}
+	return strings.Join(msgs, "\n")
+}
+
+// Translates every error by its namespace, like validator.ValidationErrors.Translate
+func (ve ValidationErrors) Translate(trans ut.Translator) validator.ValidationErrorsTranslations {
+	translations := make(validator.ValidationErrorsTranslations)
+	for _, e := range ve {
+		translations[e.Namespace()] = e.Translate(trans)
+	}
+	return translations
+}
+
+type fieldError struct {
+	tag       string
+	namespace string
+	field     string
+	message   string
+}
+
+func (e *fieldError) Tag() string             { return e.tag }
+func (e *fieldError) ActualTag() string       { return e.tag }
+func (e *fieldError) Namespace() string       { return e.namespace }
+func (e *fieldError) StructNamespace() string { return e.namespace }
+func (e *fieldError) Field() string           { return e.field }
+func (e *fieldError) StructField() string     { return e.field }
+func (e *fieldError) Value() interface{}      { return nil }
+func (e *fieldError) Param() string           { return "" }
+func (e *fieldError) Kind() reflect.Kind      { return reflect.Invalid }
+func (e *fieldError) Type() reflect.Type      { return nil }
+func (e *fieldError) Message() string         { return e.message }
+
+// Translates tag with field name and param as parameters, like translations registered
+// for validator tags, e.g. "{0} is a required field". Returns upstream message
+// if translator has no translation for the tag
+func (e *fieldError) Translate(trans ut.Translator) string {
+	if trans != nil {
+		if msg, err := trans.T(e.tag, e.field, e.Param()); err == nil {
+			return msg
+		}
+	}
+	return e.message
+}
+
+func (e *fieldError) Error() string {
+	return fmt.Sprintf("Key: '%s' Error:%s", e.namespace, e.message)
+}
+
+// Converts children of parsed errors, and messages of errors having Field, to field errors
+// in document order, children of every error sorted by name
+func FromParsedErrors(pe *jerrparser.ParsedErrors) ValidationErrors {
+	var ve ValidationErrors
+	for _, e := range pe.ParsedErrors {
+		ve = append(ve, FromParsedError(e)...)
+	}
+	return ve
+}
+
+// Converts children of parsed error, and its messages if error has Field, to field errors.
+// Namespace is built from the location of the error in document and the child key
+func FromParsedError(e jerrparser.ParsedError) ValidationErrors {
+	var ve ValidationErrors
+
+	tag := e.Code
+	if tag == "" {
+		tag = e.Category.String()
+	}
+
+	for _, fieldError := range e.FieldMessages() {
+		ve = append(ve, newFieldError(tag, fieldError.Path, fieldError.Message))
+	}
+
+	return ve
+}
+
+func newFieldError(tag string, segments []string, message string) *fieldError {
+	e := &fieldError{tag: tag, message: message}
+
+	for _, segment := range segments {
+		if _, err := strconv.Atoi(segment); err == nil && e.namespace != "" {
+			e.namespace += "[" + segment + "]"
+			continue
+		}
+
+		if e.namespace != "" {
+			e.namespace += "."
+		}
+		e.namespace += segment
+		e.field = segment
+	}
+
+	return e
+}
@@ -0,0 +1,88 @@
+package validation
+
+import (
+	"io/ioutil"
+	"reflect"
+	"testing"
+
+	"github.com/go-playground/locales/en"
+	ut "github.com/go-playground/universal-translator"
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/assert"
+
+	jerrparser "github.com/inhuman/go-json-errors-parser"
+)
+
+func TestFromParsedErrorsExample5(t *testing.T) {
+	file, e := ioutil.ReadFile("../tests/example5.json")
+	assert.NoError(t, e)
+
+	errs, err := jerrparser.Parse(file)
+	assert.NoError(t, err)
+
+	ve := FromParsedErrors(errs)
+
+	assert.Equal(t, 4, len(ve))
+	assert.Equal(t, "data.materials[1].destination", ve[2].Namespace())
+	assert.Equal(t, "data.materials[1].destination", ve[2].StructNamespace())
+	assert.Equal(t, "destination", ve[2].Field())
+	assert.Equal(t, "validation", ve[2].Tag())
+	assert.Equal(t, "Invalid Destination Directory. Every material needs a different destination directory and the directories should not be nested.", ve[2].(FieldError).Message())
+	assert.Nil(t, ve[2].Value())
+	assert.Equal(t, reflect.Invalid, ve[2].Kind())
+}
+
+func TestFromParsedErrorsKeys(t *testing.T) {
+	errs, err := jerrparser.Parse([]byte(`{"errors": {"materials.0.type": ["is unknown"], "label_template": ["is invalid"]}, "code": "invalid"}`))
+	assert.NoError(t, err)
+
+	ve := FromParsedErrors(errs)
+
+	assert.Equal(t, 2, len(ve))
+	assert.Equal(t, "label_template", ve[0].Namespace())
+	assert.Equal(t, "invalid", ve[0].Tag())
+	assert.Equal(t, "materials[0].type", ve[1].Namespace())
+	assert.Equal(t, "type", ve[1].Field())
+	assert.Equal(t, "Key: 'materials[0].type' Error:is unknown", ve[1].Error())
+	assert.Equal(t, "Key: 'label_template' Error:is invalid\nKey: 'materials[0].type' Error:is unknown", ve.Error())
+}
+
+func TestFromParsedErrorsJSONAPI(t *testing.T) {
+	errs, err := jerrparser.Parse([]byte(`{"errors": [
+		{"status": "422", "code": "too_short", "source": {"pointer": "/data/attributes/firstName"}, "detail": "is too short"}
+	]}`))
+	assert.NoError(t, err)
+
+	ve := FromParsedErrors(errs)
+
+	assert.Equal(t, 1, len(ve))
+	assert.Equal(t, "data.attributes.firstName", ve[0].Namespace())
+	assert.Equal(t, "firstName", ve[0].Field())
+	assert.Equal(t, "too_short", ve[0].Tag())
+	assert.Equal(t, "is too short", ve[0].(FieldError).Message())
+}
+
+func TestTranslate(t *testing.T) {
+	errs, err := jerrparser.Parse([]byte(`{"errors": [
+		{"status": "422", "code": "too_short", "source": {"pointer": "/data/attributes/firstName"}, "detail": "is too short"},
+		{"status": "422", "code": "taken", "source": {"pointer": "/data/attributes/email"}, "detail": "is taken"}
+	]}`))
+	assert.NoError(t, err)
+
+	locale := en.New()
+	trans, _ := ut.New(locale, locale).GetTranslator("en")
+	assert.NoError(t, trans.Add("too_short", "{0} is too short", false))
+
+	ve := FromParsedErrors(errs)
+
+	// converts to validator errors, so code and templates typed with them take upstream errors
+	fieldErrors := validator.ValidationErrors(ve)
+	assert.Equal(t, "firstName is too short", fieldErrors[0].Translate(trans))
+	// upstream message without translation for the tag
+	assert.Equal(t, "is taken", fieldErrors[1].Translate(trans))
+
+	assert.Equal(t, validator.ValidationErrorsTranslations{
+		"data.attributes.firstName": "firstName is too short",
+		"data.attributes.email":     "is taken",
+	}, ve.Translate(trans))
+}
@@ -0,0 +1,137 @@
+package go_json_errors_parser
+
+import (
+	"encoding/json"
+	"strconv"
+)
+
+// Kind of json value
+type ValueKind int
+
+const (
+	NullValue ValueKind = iota
+	StringValue
+	NumberValue
+	BoolValue
+	ObjectValue
+	ArrayValue
+)
+
+var valueKindNames = []string{"null", "string", "number", "bool", "object", "array"}
+
+func (k ValueKind) String() string {
+	if k < 0 || int(k) >= len(valueKindNames) {
+		return "unknown"
+	}
+	return valueKindNames[k]
+}
+
+// Value is original json value of child entry
+type Value struct {
+	Kind ValueKind
+	// string, json.Number, bool or nil for scalar values,
+	// map[string]interface{} or []interface{} for objects and arrays
+	Value interface{}
+	Raw   json.RawMessage
+}
+
+// Returns string values as is, numbers as they are written in json and other values as json
+func (v Value) String() string {
+	switch value := v.Value.(type) {
+	case string:
+		return value
+	case json.Number:
+		return value.String()
+	case bool:
+		return strconv.FormatBool(value)
+	}
+	return string(v.Raw)
+}
+
+// Appends child with its original value
+// Returns Value of string decoded from json, for children taken from unmarshaled structs
+func stringValue(s string) Value {
+	raw, _ := json.Marshal(s)
+	return Value{Kind: StringValue, Value: s, Raw: raw}
+}
+
+func (e *ParsedError) addChild(name string, value Value, pointer string) {
+	if e.Children == nil {
+		e.Children = make(map[string][]string)
+		e.ChildPointers = make(map[string][]string)
+		e.Values = make(map[string][]Value)
+	}
+
+	e.Children[name] = append(e.Children[name], value.String())
+	e.ChildPointers[name] = append(e.ChildPointers[name], pointer)
+	e.Values[name] = append(e.Values[name], value)
+}
+
+// Removes null children, returns true if error had only null children
+func (e *ParsedError) dropNulls() bool {
+	dropped := false
+
+	for name, values := range e.Values {
+		var children, pointers []string
+		var kept []Value
+
+		for i, value := range values {
+			if value.Kind == NullValue {
+				continue
+			}
+			kept = append(kept, value)
+			children = append(children, e.Children[name][i])
+			pointers = append(pointers, e.ChildPointers[name][i])
+		}
+
+		if len(kept) == len(values) {
+			continue
+		}
+		dropped = true
+
+		if len(kept) == 0 {
+			delete(e.Children, name)
+			delete(e.ChildPointers, name)
+			delete(e.Values, name)
+			continue
+		}
+
+		e.Children[name] = children
+		e.ChildPointers[name] = pointers
+		e.Values[name] = kept
+	}
+
+	return dropped && len(e.Messages) == 0 && len(e.Children) == 0
+}
+
+// Nested object or array found in extracted value, extracted separately
+type nestedValue struct {
+	node   *node
+	parent string
+	path   []string
+}
+
+// Implemented by extractors collecting nested values instead of stringifying them
+type nestedExtractor interface {
+	nestedValues() []nestedValue
+}
+
+// Embedded by extractors to collect nested values
+type nestedCollector struct {
+	nested []nestedValue
+}
+
+func (c *nestedCollector) addNested(n *node, parent string, path []string) {
+	c.nested = append(c.nested, nestedValue{node: n, parent: parent, path: path})
+}
+
+func (c *nestedCollector) nestedValues() []nestedValue {
+	return c.nested
+}
+
+// Keeps null children, rendered as "null"
+func WithNulls() Option {
+	return func(p *Parser) {
+		p.nulls = true
+	}
+}
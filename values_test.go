@@ -0,0 +1,86 @@
+package go_json_errors_parser
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"testing"
+)
+
+func TestValuesExample1(t *testing.T) {
+	file, e := ioutil.ReadFile("tests/example1.json")
+	assert.NoError(t, e)
+
+	errs, err := Parse(file)
+	assert.NoError(t, err)
+
+	for _, parsedError := range errs.ParsedErrors {
+		if parsedError.Parent != "data" {
+			continue
+		}
+
+		values := parsedError.Values["PACKAGE_SPEC"]
+		assert.Equal(t, 2, len(values))
+		assert.Equal(t, StringValue, values[0].Kind)
+		assert.Equal(t, NumberValue, values[1].Kind)
+		assert.Equal(t, json.Number("4"), values[1].Value)
+		assert.Equal(t, []string{"Package spec not specified", "4"}, parsedError.Children["PACKAGE_SPEC"])
+		return
+	}
+
+	t.Fatal("PACKAGE_SPEC errors not found")
+}
+
+func TestValuesScalars(t *testing.T) {
+	errs, err := Parse([]byte(`{"errors": {"count": 12345678901234567890, "ratio": 0.5, "fatal": true, "code": "E1"}}`))
+	assert.NoError(t, err)
+	assert.Equal(t, 1, errs.GetCount())
+
+	parsedError := errs.ParsedErrors[0]
+	assert.Equal(t, []string{"12345678901234567890"}, parsedError.Children["count"])
+	assert.Equal(t, []string{"0.5"}, parsedError.Children["ratio"])
+	assert.Equal(t, []string{"true"}, parsedError.Children["fatal"])
+	assert.Equal(t, BoolValue, parsedError.Values["fatal"][0].Kind)
+	assert.Equal(t, true, parsedError.Values["fatal"][0].Value)
+	assert.Equal(t, StringValue, parsedError.Values["code"][0].Kind)
+}
+
+func TestValuesNulls(t *testing.T) {
+	data := []byte(`{"errors": {"name": ["required", null], "email": [null]}}`)
+
+	errs, err := Parse(data)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, errs.GetCount())
+	assert.Equal(t, map[string][]string{"name": {"required"}}, errs.ParsedErrors[0].Children)
+	assert.Equal(t, map[string][]string{"name": {"/errors/name/0"}}, errs.ParsedErrors[0].ChildPointers)
+
+	errs, err = NewParser(WithNulls()).Parse(data)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"required", "null"}, errs.ParsedErrors[0].Children["name"])
+	assert.Equal(t, NullValue, errs.ParsedErrors[0].Values["email"][0].Kind)
+
+	errs, err = Parse([]byte(`{"errors": {"email": [null]}}`))
+	assert.NoError(t, err)
+	assert.Equal(t, false, errs.IsErrors())
+}
+
+func TestValuesNested(t *testing.T) {
+	errs, err := Parse([]byte(`{"errors": {"address": [{"city": ["too long"]}]}}`))
+	assert.NoError(t, err)
+
+	var rendered []string
+	for _, err := range errs.GetErrors(WithPointers()) {
+		rendered = append(rendered, err.Error())
+	}
+	assert.Equal(t, []string{"[/errors/address/0/city/0] too long"}, rendered)
+	assert.Equal(t, 1, errs.GetCount())
+	assert.Equal(t, "address", errs.ParsedErrors[0].Parent)
+
+	for _, parsedError := range errs.ParsedErrors {
+		for _, children := range parsedError.Children {
+			for _, child := range children {
+				assert.NotContains(t, child, "map[")
+			}
+		}
+	}
+}